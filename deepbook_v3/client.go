@@ -207,7 +207,9 @@ func (c *Client) MidPrice(ctx context.Context, poolKey string) (float64, error)
 
 func (c *Client) GetOrder(ctx context.Context, poolKey, orderID string) (string, error) {
 	tx := stx.NewTransaction()
-	c.DeepBook.GetOrder(tx, poolKey, orderID)
+	if _, err := c.DeepBook.GetOrder(tx, poolKey, orderID); err != nil {
+		return "", err
+	}
 	res, err := c.simulate(ctx, tx)
 	if err != nil {
 		return "", err
@@ -217,7 +219,9 @@ func (c *Client) GetOrder(ctx context.Context, poolKey, orderID string) (string,
 
 func (c *Client) GetOrders(ctx context.Context, poolKey string, orderIDs []string) (string, error) {
 	tx := stx.NewTransaction()
-	c.DeepBook.GetOrders(tx, poolKey, orderIDs)
+	if _, err := c.DeepBook.GetOrders(tx, poolKey, orderIDs); err != nil {
+		return "", err
+	}
 	res, err := c.simulate(ctx, tx)
 	if err != nil {
 		return "", err
@@ -421,12 +425,19 @@ func (c *Client) GetPythClient(pythStateID, wormholeStateID string) *pyth.SuiPyt
 }
 
 func (c *Client) simulate(ctx context.Context, tx *stx.Transaction) (map[string]any, error) {
-	built, err := tx.BuildBase64()
+	tx.SetSenderIfNotSet(c.Address)
+	// Read-only calls are dry-run against a mock gas coin, so skip budget
+	// estimation and coin selection.
+	tx.SetGasBudgetIfNotSet(stx.MaxGas)
+	if tx.GetData().GasData.Payment == nil {
+		tx.SetGasPayment([]stx.ObjectRef{})
+	}
+	built, err := tx.BuildContext(ctx, stx.BuildTransactionOptions{Client: c.client})
 	if err != nil {
 		return nil, err
 	}
 	var out map[string]any
-	err = c.client.Call(ctx, "sui_dryRunTransactionBlock", []any{built}, &out)
+	err = c.client.Call(ctx, "sui_dryRunTransactionBlock", []any{base64.StdEncoding.EncodeToString(built)}, &out)
 	return out, err
 }
 
@@ -10,8 +10,9 @@ type emptyResponseClient struct{}
 func (e emptyResponseClient) Network() string { return "testnet" }
 func (e emptyResponseClient) Call(ctx context.Context, method string, params []any, out any) error {
 	_ = ctx
-	_ = method
-	_ = params
+	if resolveMockCall(method, params, out) {
+		return nil
+	}
 	if p, ok := out.(*map[string]any); ok {
 		*p = map[string]any{}
 	}
@@ -37,3 +38,33 @@ func TestClientHandlesMissingReturnValues(t *testing.T) {
 		t.Fatalf("expected error for missing returnValues")
 	}
 }
+
+type ctxKey struct{}
+
+// ctxRecordingClient records the methods called and those called without
+// the caller's context.
+type ctxRecordingClient struct {
+	emptyResponseClient
+	calls, detached []string
+}
+
+func (c *ctxRecordingClient) Call(ctx context.Context, method string, params []any, out any) error {
+	c.calls = append(c.calls, method)
+	if ctx.Value(ctxKey{}) == nil {
+		c.detached = append(c.detached, method)
+	}
+	return c.emptyResponseClient.Call(ctx, method, params, out)
+}
+
+func TestSimulatePassesContextToBuild(t *testing.T) {
+	rec := &ctxRecordingClient{}
+	c := NewClient(ClientOptions{Client: rec, Network: "testnet", Options: Options{Address: "0x1"}})
+	ctx := context.WithValue(context.Background(), ctxKey{}, true)
+	_, _ = c.Whitelisted(ctx, "DEEP_SUI")
+	if len(rec.calls) < 2 {
+		t.Fatalf("expected build and dry run calls, got %v", rec.calls)
+	}
+	if len(rec.detached) != 0 {
+		t.Fatalf("calls made without the caller's context: %v", rec.detached)
+	}
+}
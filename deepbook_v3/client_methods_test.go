@@ -3,12 +3,12 @@ package deepbookv3
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"testing"
 
 	"github.com/sui-sdks/go-sdks/bcs"
 	"github.com/sui-sdks/go-sdks/deepbook_v3/types"
 	"github.com/sui-sdks/go-sdks/deepbook_v3/utils"
+	stx "github.com/sui-sdks/go-sdks/sui/transactions"
 )
 
 type deepbookMethodMockClient struct{}
@@ -22,15 +22,14 @@ func encodeU64(v uint64) string {
 }
 
 func moveFunctionFromTxBase64(txb64 string) string {
-	raw, err := base64.StdEncoding.DecodeString(txb64)
+	parsed, err := stx.BCS.TransactionData.FromBase64(txb64)
 	if err != nil {
 		return ""
 	}
-	var payload map[string]any
-	if err := json.Unmarshal(raw, &payload); err != nil {
-		return ""
-	}
-	cmds, ok := payload["Commands"].([]any)
+	v1, _ := parsed.(map[string]any)["V1"].(map[string]any)
+	kind, _ := v1["kind"].(map[string]any)
+	ptb, _ := kind["ProgrammableTransaction"].(map[string]any)
+	cmds, ok := ptb["commands"].([]any)
 	if !ok || len(cmds) == 0 {
 		return ""
 	}
@@ -48,6 +47,9 @@ func moveFunctionFromTxBase64(txb64 string) string {
 
 func (m deepbookMethodMockClient) Call(ctx context.Context, method string, params []any, out any) error {
 	_ = ctx
+	if resolveMockCall(method, params, out) {
+		return nil
+	}
 	if method != "sui_dryRunTransactionBlock" {
 		return nil
 	}
@@ -5,15 +5,44 @@ import (
 	"encoding/base64"
 	"testing"
 
+	"github.com/sui-sdks/go-sdks/bcs"
 	"github.com/sui-sdks/go-sdks/deepbook_v3/types"
 )
 
 type mockClient struct{}
 
+// resolveMockCall answers the object and gas lookups issued while building a transaction.
+func resolveMockCall(method string, params []any, out any) bool {
+	switch method {
+	case "suix_getReferenceGasPrice":
+		if p, ok := out.(*string); ok {
+			*p = "1000"
+		}
+		return true
+	case "sui_multiGetObjects":
+		ids, _ := params[0].([]string)
+		objects := make([]map[string]any, len(ids))
+		for i, id := range ids {
+			objects[i] = map[string]any{"data": map[string]any{
+				"objectId": id,
+				"version":  "1",
+				"digest":   bcs.ToBase58(make([]byte, 32)),
+			}}
+		}
+		if p, ok := out.(*[]map[string]any); ok {
+			*p = objects
+		}
+		return true
+	}
+	return false
+}
+
 func (m mockClient) Network() string { return "testnet" }
 func (m mockClient) Call(ctx context.Context, method string, params []any, out any) error {
 	_ = ctx
-	_ = params
+	if resolveMockCall(method, params, out) {
+		return nil
+	}
 	if p, ok := out.(*map[string]any); ok {
 		switch method {
 		case "sui_dryRunTransactionBlock":
@@ -50,13 +50,17 @@ func NewSuiPythClient(provider CoreClient, pythStateID, wormholeStateID string)
 	return &SuiPythClient{provider: provider, PythStateID: pythStateID, WormholeStateID: wormholeStateID}
 }
 
-func (c *SuiPythClient) VerifyVaas(tx *stx.Transaction, vaas [][]byte) []stx.Argument {
+func (c *SuiPythClient) VerifyVaas(tx *stx.Transaction, vaas [][]byte) ([]stx.Argument, error) {
 	out := make([]stx.Argument, 0, len(vaas))
 	for _, vaa := range vaas {
-		arg := tx.MoveCall("wormhole::vaa::parse_and_verify", []stx.Argument{tx.Object(c.WormholeStateID), tx.PureBytes(vaa), tx.Object("0x6")}, nil)
+		vaaArg, err := tx.Pure().Vector("u8", vaa)
+		if err != nil {
+			return nil, err
+		}
+		arg := tx.MoveCall("wormhole::vaa::parse_and_verify", []stx.Argument{tx.Object(c.WormholeStateID), vaaArg, tx.Object("0x6")}, nil)
 		out = append(out, arg)
 	}
-	return out
+	return out, nil
 }
 
 func (c *SuiPythClient) UpdatePriceFeeds(tx *stx.Transaction, updates [][]byte, feedIDs []string) ([]string, error) {
@@ -25,8 +25,12 @@ func (c *BalanceManagerContract) CreateAndShareBalanceManager(tx *stx.Transactio
 	return manager
 }
 
-func (c *BalanceManagerContract) CreateBalanceManagerWithOwner(tx *stx.Transaction, ownerAddress string) stx.Argument {
-	return tx.MoveCall(c.target("new_with_custom_owner"), []stx.Argument{tx.PureBytes([]byte(ownerAddress))}, nil)
+func (c *BalanceManagerContract) CreateBalanceManagerWithOwner(tx *stx.Transaction, ownerAddress string) (stx.Argument, error) {
+	owner, err := pureAddress(tx, ownerAddress)
+	if err != nil {
+		return stx.Argument{}, err
+	}
+	return tx.MoveCall(c.target("new_with_custom_owner"), []stx.Argument{owner}, nil), nil
 }
 
 func (c *BalanceManagerContract) ShareBalanceManager(tx *stx.Transaction, manager stx.Argument) stx.Argument {
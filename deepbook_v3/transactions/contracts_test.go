@@ -30,8 +30,7 @@ func commandFunction(tx *stx.Transaction, idx int) string {
 	if len(data.Commands) <= idx {
 		return ""
 	}
-	mv := data.Commands[idx]["MoveCall"].(map[string]any)
-	return mv["function"].(string)
+	return data.Commands[idx].MoveCall.Function
 }
 
 func lastFunction(tx *stx.Transaction) string {
@@ -162,7 +161,9 @@ func TestMarginContractsTargets(t *testing.T) {
 
 	mr := NewMarginRegistryContract(cfg)
 	tx3 := stx.NewTransaction()
-	mr.GetMarginManagerIDs(tx3, "0x1")
+	if _, err := mr.GetMarginManagerIDs(tx3, "0x1"); err != nil {
+		t.Fatalf("get margin manager ids failed: %v", err)
+	}
 	if got := firstFunction(tx3); got != "get_margin_manager_ids" {
 		t.Fatalf("expected get_margin_manager_ids, got %s", got)
 	}
@@ -26,7 +26,11 @@ func (c *DeepBookContract) poolTarget(fn string) string {
 	return c.config.DeepbookPackageID + "::pool::" + fn
 }
 
-func (c *DeepBookContract) PlaceLimitOrder(tx *stx.Transaction, params types.PlaceLimitOrderParams) stx.Argument {
+func (c *DeepBookContract) PlaceLimitOrder(tx *stx.Transaction, params types.PlaceLimitOrderParams) (stx.Argument, error) {
+	clientOrderID, err := parseU64(params.ClientOrderID)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	if params.Expiration == 0 {
 		params.Expiration = utils.MaxTimestamp
 	}
@@ -40,7 +44,7 @@ func (c *DeepBookContract) PlaceLimitOrder(tx *stx.Transaction, params types.Pla
 		tx.Object(pool.Address),
 		tx.Object(manager.Address),
 		proof,
-		pureU64(tx, parseU64(params.ClientOrderID)),
+		pureU64(tx, clientOrderID),
 		pureU8(tx, uint8(params.OrderType)),
 		pureU8(tx, uint8(params.SelfMatchingOption)),
 		pureU64(tx, price),
@@ -49,10 +53,14 @@ func (c *DeepBookContract) PlaceLimitOrder(tx *stx.Transaction, params types.Pla
 		pureBool(tx, params.PayWithDeep),
 		pureU64(tx, params.Expiration),
 		tx.Object("0x6"),
-	}, []string{base.Type, quote.Type})
+	}, []string{base.Type, quote.Type}), nil
 }
 
-func (c *DeepBookContract) PlaceMarketOrder(tx *stx.Transaction, params types.PlaceMarketOrderParams) stx.Argument {
+func (c *DeepBookContract) PlaceMarketOrder(tx *stx.Transaction, params types.PlaceMarketOrderParams) (stx.Argument, error) {
+	clientOrderID, err := parseU64(params.ClientOrderID)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	base, quote, pool := c.poolTypes(params.PoolKey)
 	manager := c.config.GetBalanceManager(params.BalanceManagerKey)
 	qty := uint64(math.Round(params.Quantity * base.Scalar))
@@ -62,43 +70,55 @@ func (c *DeepBookContract) PlaceMarketOrder(tx *stx.Transaction, params types.Pl
 		tx.Object(pool.Address),
 		tx.Object(manager.Address),
 		proof,
-		pureU64(tx, parseU64(params.ClientOrderID)),
+		pureU64(tx, clientOrderID),
 		pureU8(tx, uint8(params.SelfMatchingOption)),
 		pureU64(tx, qty),
 		pureBool(tx, params.IsBid),
 		pureBool(tx, params.PayWithDeep),
 		tx.Object("0x6"),
-	}, []string{base.Type, quote.Type})
+	}, []string{base.Type, quote.Type}), nil
 }
 
-func (c *DeepBookContract) ModifyOrder(tx *stx.Transaction, poolKey, balanceManagerKey, orderID string, newQuantity float64) stx.Argument {
+func (c *DeepBookContract) ModifyOrder(tx *stx.Transaction, poolKey, balanceManagerKey, orderID string, newQuantity float64) (stx.Argument, error) {
+	id, err := pureU128String(tx, orderID)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	base, quote, pool := c.poolTypes(poolKey)
 	manager := c.config.GetBalanceManager(balanceManagerKey)
 	qty := uint64(math.Round(newQuantity * base.Scalar))
 	proof := c.balanceManager.GenerateProof(tx, balanceManagerKey)
 	return tx.MoveCall(c.poolTarget("modify_order"), []stx.Argument{
-		tx.Object(pool.Address), tx.Object(manager.Address), proof, pureU128String(tx, orderID), pureU64(tx, qty), tx.Object("0x6"),
-	}, []string{base.Type, quote.Type})
+		tx.Object(pool.Address), tx.Object(manager.Address), proof, id, pureU64(tx, qty), tx.Object("0x6"),
+	}, []string{base.Type, quote.Type}), nil
 }
 
-func (c *DeepBookContract) CancelOrder(tx *stx.Transaction, poolKey, balanceManagerKey, orderID string) stx.Argument {
+func (c *DeepBookContract) CancelOrder(tx *stx.Transaction, poolKey, balanceManagerKey, orderID string) (stx.Argument, error) {
+	id, err := pureU128String(tx, orderID)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	base, quote, pool := c.poolTypes(poolKey)
 	manager := c.config.GetBalanceManager(balanceManagerKey)
 	proof := c.balanceManager.GenerateProof(tx, balanceManagerKey)
 	tx.SetGasBudgetIfNotSet(utils.GasBudget)
 	return tx.MoveCall(c.poolTarget("cancel_order"), []stx.Argument{
-		tx.Object(pool.Address), tx.Object(manager.Address), proof, pureU128String(tx, orderID), tx.Object("0x6"),
-	}, []string{base.Type, quote.Type})
+		tx.Object(pool.Address), tx.Object(manager.Address), proof, id, tx.Object("0x6"),
+	}, []string{base.Type, quote.Type}), nil
 }
 
-func (c *DeepBookContract) CancelOrders(tx *stx.Transaction, poolKey, balanceManagerKey string, orderIDs []string) stx.Argument {
+func (c *DeepBookContract) CancelOrders(tx *stx.Transaction, poolKey, balanceManagerKey string, orderIDs []string) (stx.Argument, error) {
+	ids, err := pureVecU128(tx, orderIDs)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	base, quote, pool := c.poolTypes(poolKey)
 	manager := c.config.GetBalanceManager(balanceManagerKey)
 	proof := c.balanceManager.GenerateProof(tx, balanceManagerKey)
 	tx.SetGasBudgetIfNotSet(utils.GasBudget)
 	return tx.MoveCall(c.poolTarget("cancel_orders"), []stx.Argument{
-		tx.Object(pool.Address), tx.Object(manager.Address), proof, pureVecU128(tx, orderIDs), tx.Object("0x6"),
-	}, []string{base.Type, quote.Type})
+		tx.Object(pool.Address), tx.Object(manager.Address), proof, ids, tx.Object("0x6"),
+	}, []string{base.Type, quote.Type}), nil
 }
 
 func (c *DeepBookContract) CancelAllOrders(tx *stx.Transaction, poolKey, balanceManagerKey string) stx.Argument {
@@ -159,14 +179,22 @@ func (c *DeepBookContract) UpdatePoolAllowedVersions(tx *stx.Transaction, poolKe
 	}, []string{base.Type, quote.Type})
 }
 
-func (c *DeepBookContract) GetOrder(tx *stx.Transaction, poolKey, orderID string) stx.Argument {
+func (c *DeepBookContract) GetOrder(tx *stx.Transaction, poolKey, orderID string) (stx.Argument, error) {
+	id, err := pureU128String(tx, orderID)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	base, quote, pool := c.poolTypes(poolKey)
-	return tx.MoveCall(c.poolTarget("get_order"), []stx.Argument{tx.Object(pool.Address), pureU128String(tx, orderID)}, []string{base.Type, quote.Type})
+	return tx.MoveCall(c.poolTarget("get_order"), []stx.Argument{tx.Object(pool.Address), id}, []string{base.Type, quote.Type}), nil
 }
 
-func (c *DeepBookContract) GetOrders(tx *stx.Transaction, poolKey string, orderIDs []string) stx.Argument {
+func (c *DeepBookContract) GetOrders(tx *stx.Transaction, poolKey string, orderIDs []string) (stx.Argument, error) {
+	ids, err := pureVecU128(tx, orderIDs)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	base, quote, pool := c.poolTypes(poolKey)
-	return tx.MoveCall(c.poolTarget("get_orders"), []stx.Argument{tx.Object(pool.Address), pureVecU128(tx, orderIDs)}, []string{base.Type, quote.Type})
+	return tx.MoveCall(c.poolTarget("get_orders"), []stx.Argument{tx.Object(pool.Address), ids}, []string{base.Type, quote.Type}), nil
 }
 
 func (c *DeepBookContract) BurnDeep(tx *stx.Transaction, poolKey string) stx.Argument {
@@ -261,8 +289,12 @@ func (c *DeepBookContract) GetPoolDeepPrice(tx *stx.Transaction, poolKey string)
 	return tx.MoveCall(c.poolTarget("get_order_deep_price"), []stx.Argument{tx.Object(pool.Address)}, []string{base.Type, quote.Type})
 }
 
-func (c *DeepBookContract) GetBalanceManagerIDs(tx *stx.Transaction, owner string) stx.Argument {
-	return tx.MoveCall(c.config.DeepbookPackageID+"::registry::get_balance_manager_ids", []stx.Argument{tx.PureBytes([]byte(owner)), tx.Object(c.config.RegistryID)}, nil)
+func (c *DeepBookContract) GetBalanceManagerIDs(tx *stx.Transaction, owner string) (stx.Argument, error) {
+	ownerArg, err := pureAddress(tx, owner)
+	if err != nil {
+		return stx.Argument{}, err
+	}
+	return tx.MoveCall(c.config.DeepbookPackageID+"::registry::get_balance_manager_ids", []stx.Argument{ownerArg, tx.Object(c.config.RegistryID)}, nil), nil
 }
 
 func (c *DeepBookContract) GetPoolReferralBalances(tx *stx.Transaction, poolKey, referral string) stx.Argument {
@@ -68,10 +68,14 @@ func (c *DeepBookAdminContract) DisableVersion(tx *stx.Transaction, version uint
 	}, nil)
 }
 
-func (c *DeepBookAdminContract) SetTreasuryAddress(tx *stx.Transaction, treasuryAddress string) stx.Argument {
+func (c *DeepBookAdminContract) SetTreasuryAddress(tx *stx.Transaction, treasuryAddress string) (stx.Argument, error) {
+	treasury, err := pureAddress(tx, treasuryAddress)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	return tx.MoveCall(c.config.DeepbookPackageID+"::registry::set_treasury_address", []stx.Argument{
-		tx.Object(c.config.RegistryID), tx.PureBytes([]byte(treasuryAddress)), tx.Object(c.adminCap()),
-	}, nil)
+		tx.Object(c.config.RegistryID), treasury, tx.Object(c.adminCap()),
+	}, nil), nil
 }
 
 func (c *DeepBookAdminContract) AddStableCoin(tx *stx.Transaction, stableCoinKey string) stx.Argument {
@@ -1,61 +1,59 @@
 package transactions
 
 import (
-	"math"
+	"fmt"
 	"math/big"
 	"strconv"
 
-	"github.com/sui-sdks/go-sdks/bcs"
 	stx "github.com/sui-sdks/go-sdks/sui/transactions"
 )
 
 func pureBool(tx *stx.Transaction, v bool) stx.Argument {
-	b := byte(0)
-	if v {
-		b = 1
-	}
-	return tx.PureBytes([]byte{b})
+	return tx.Pure().Bool(v)
 }
 
 func pureU8(tx *stx.Transaction, v uint8) stx.Argument {
-	return tx.PureBytes([]byte{v})
+	return tx.Pure().U8(v)
 }
 
 func pureU64(tx *stx.Transaction, v uint64) stx.Argument {
-	w := bcs.NewWriter(nil)
-	_ = w.Write64(v)
-	return tx.PureBytes(w.ToBytes())
+	return tx.Pure().U64(v)
 }
 
-func pureU128String(tx *stx.Transaction, s string) stx.Argument {
-	n := new(big.Int)
-	if _, ok := n.SetString(s, 10); !ok {
-		n.SetUint64(0)
-	}
-	out := make([]byte, 16)
-	b := n.Bytes()
-	for i := 0; i < len(b) && i < 16; i++ {
-		out[i] = b[len(b)-1-i]
+func pureAddress(tx *stx.Transaction, address string) (stx.Argument, error) {
+	return tx.Pure().Address(address)
+}
+
+func pureU128String(tx *stx.Transaction, s string) (stx.Argument, error) {
+	n, err := parseU128(s)
+	if err != nil {
+		return stx.Argument{}, err
 	}
-	return tx.PureBytes(out)
+	return tx.Pure().U128(n)
 }
 
-func pureVecU128(tx *stx.Transaction, values []string) stx.Argument {
-	w := bcs.NewWriter(nil)
-	_ = w.WriteULEB(uint64(len(values)))
-	for _, s := range values {
-		n := new(big.Int)
-		if _, ok := n.SetString(s, 10); !ok {
-			n.SetUint64(0)
-		}
-		out := make([]byte, 16)
-		b := n.Bytes()
-		for i := 0; i < len(b) && i < 16; i++ {
-			out[i] = b[len(b)-1-i]
+func pureVecU128(tx *stx.Transaction, values []string) (stx.Argument, error) {
+	ids := make([]*big.Int, len(values))
+	for i, s := range values {
+		n, err := parseU128(s)
+		if err != nil {
+			return stx.Argument{}, err
 		}
-		_ = w.WriteBytes(out)
+		ids[i] = n
 	}
-	return tx.PureBytes(w.ToBytes())
+	return tx.Pure().Vector("u128", ids)
+}
+
+var maxU128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+// parseU128 parses a decimal u128, rejecting anything that is not a base-10
+// integer in [0, 2^128).
+func parseU128(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok || n.Sign() < 0 || n.Cmp(maxU128) > 0 {
+		return nil, fmt.Errorf("invalid u128 %q", s)
+	}
+	return n, nil
 }
 
 func boolToByte(v bool) byte {
@@ -65,11 +63,11 @@ func boolToByte(v bool) byte {
 	return 0
 }
 
-func parseU64(s string) uint64 {
-	return uint64(math.Round(parseFloat(s)))
-}
-
-func parseFloat(s string) float64 {
-	v, _ := strconv.ParseFloat(s, 64)
-	return v
+// parseU64 parses a decimal u64 exactly, without going through float64.
+func parseU64(s string) (uint64, error) {
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid u64 %q", s)
+	}
+	return v, nil
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/sui-sdks/go-sdks/bcs"
+	"github.com/sui-sdks/go-sdks/deepbook_v3/types"
 	stx "github.com/sui-sdks/go-sdks/sui/transactions"
 )
 
@@ -24,7 +25,10 @@ func TestPureU64Encoding(t *testing.T) {
 
 func TestPureU128StringEncoding(t *testing.T) {
 	tx := stx.NewTransaction()
-	arg := pureU128String(tx, "340282366920938463463374607431768211455")
+	arg, err := pureU128String(tx, "340282366920938463463374607431768211455")
+	if err != nil {
+		t.Fatalf("encode u128 failed: %v", err)
+	}
 	got := readU128StringFromArg(t, tx, arg)
 	if got != "340282366920938463463374607431768211455" {
 		t.Fatalf("u128 mismatch: got %s", got)
@@ -33,7 +37,10 @@ func TestPureU128StringEncoding(t *testing.T) {
 
 func TestPureVecU128Encoding(t *testing.T) {
 	tx := stx.NewTransaction()
-	arg := pureVecU128(tx, []string{"1", "2", "3"})
+	arg, err := pureVecU128(tx, []string{"1", "2", "3"})
+	if err != nil {
+		t.Fatalf("encode vector<u128> failed: %v", err)
+	}
 	raw := pureBytesFromArg(t, tx, arg)
 	r := bcs.NewReader(raw)
 	n, err := r.ReadULEB()
@@ -55,8 +62,59 @@ func TestPureVecU128Encoding(t *testing.T) {
 	}
 }
 
-func TestParseU64Fallback(t *testing.T) {
-	if got := parseU64("not-number"); got != 0 {
-		t.Fatalf("expected fallback 0, got %d", got)
+func TestParseU64(t *testing.T) {
+	// 2^53+1 is the first integer a float64 cannot hold.
+	if got, err := parseU64("9007199254740993"); err != nil || got != 9007199254740993 {
+		t.Fatalf("parse u64 mismatch: got %d, %v", got, err)
+	}
+	for _, s := range []string{"not-number", "", "-1", "1.5", "18446744073709551616"} {
+		if _, err := parseU64(s); err == nil {
+			t.Fatalf("expected %q to be rejected", s)
+		}
+	}
+}
+
+func TestParseU128Rejects(t *testing.T) {
+	for _, s := range []string{"not-number", "", "-1", "340282366920938463463374607431768211456"} {
+		if _, err := parseU128(s); err == nil {
+			t.Fatalf("expected %q to be rejected", s)
+		}
+	}
+}
+
+func TestPureAddressEncoding(t *testing.T) {
+	tx := stx.NewTransaction()
+	arg, err := pureAddress(tx, "0x2")
+	if err != nil {
+		t.Fatalf("encode address failed: %v", err)
+	}
+	b := pureBytesFromArg(t, tx, arg)
+	if len(b) != 32 || b[31] != 2 {
+		t.Fatalf("unexpected address bytes: %x", b)
+	}
+}
+
+func TestInvalidAddressIsRejected(t *testing.T) {
+	tx := stx.NewTransaction()
+	if _, err := NewBalanceManagerContract(newTestConfig()).CreateBalanceManagerWithOwner(tx, "0xnot-an-address"); err == nil {
+		t.Fatalf("expected an invalid owner address to be rejected")
+	}
+	if data := tx.GetData(); len(data.Inputs) != 0 || len(data.Commands) != 0 {
+		t.Fatalf("expected nothing to be added, got %d inputs and %d commands", len(data.Inputs), len(data.Commands))
+	}
+}
+
+func TestInvalidOrderIDIsRejected(t *testing.T) {
+	cfg := newTestConfig()
+	c := NewDeepBookContract(cfg, NewBalanceManagerContract(cfg))
+	tx := stx.NewTransaction()
+	if _, err := c.CancelOrders(tx, "DEEP_SUI", "m1", []string{"1", "340282366920938463463374607431768211456"}); err == nil {
+		t.Fatalf("expected an out-of-range order id to be rejected")
+	}
+	if _, err := c.PlaceLimitOrder(tx, types.PlaceLimitOrderParams{PoolKey: "DEEP_SUI", BalanceManagerKey: "m1", ClientOrderID: "1.5"}); err == nil {
+		t.Fatalf("expected a fractional client order id to be rejected")
+	}
+	if data := tx.GetData(); len(data.Inputs) != 0 || len(data.Commands) != 0 {
+		t.Fatalf("expected nothing to be added, got %d inputs and %d commands", len(data.Inputs), len(data.Commands))
 	}
 }
@@ -1,7 +1,6 @@
 package transactions
 
 import (
-	"encoding/base64"
 	"math"
 	"math/big"
 	"testing"
@@ -12,67 +11,35 @@ import (
 	stx "github.com/sui-sdks/go-sdks/sui/transactions"
 )
 
-func findMoveCallByFunction(t *testing.T, tx *stx.Transaction, function string) map[string]any {
+func findMoveCallByFunction(t *testing.T, tx *stx.Transaction, function string) *stx.MoveCall {
 	t.Helper()
 	for _, cmd := range tx.GetData().Commands {
-		mv, ok := cmd["MoveCall"].(map[string]any)
-		if !ok {
-			continue
-		}
-		if fn, _ := mv["function"].(string); fn == function {
-			return mv
+		if cmd.MoveCall != nil && cmd.MoveCall.Function == function {
+			return cmd.MoveCall
 		}
 	}
 	t.Fatalf("move call %q not found", function)
 	return nil
 }
 
-func argsFromMoveCall(t *testing.T, mv map[string]any) []stx.Argument {
+func argsFromMoveCall(t *testing.T, mv *stx.MoveCall) []stx.Argument {
 	t.Helper()
-	raw, ok := mv["arguments"]
-	if !ok {
+	if mv.Arguments == nil {
 		t.Fatalf("missing arguments")
 	}
-	switch v := raw.(type) {
-	case []stx.Argument:
-		return v
-	case []any:
-		out := make([]stx.Argument, 0, len(v))
-		for _, it := range v {
-			a, ok := it.(map[string]any)
-			if !ok {
-				t.Fatalf("invalid argument item")
-			}
-			out = append(out, stx.Argument(a))
-		}
-		return out
-	default:
-		t.Fatalf("unexpected arguments type %T", raw)
-	}
-	return nil
+	return mv.Arguments
 }
 
 func pureBytesFromArg(t *testing.T, tx *stx.Transaction, arg stx.Argument) []byte {
 	t.Helper()
-	kind, _ := arg["$kind"].(string)
-	if kind != "Input" {
-		t.Fatalf("argument is not Input kind: %v", kind)
+	if arg.Kind != stx.ArgumentInput {
+		t.Fatalf("argument is not Input kind: %v", arg.Kind)
 	}
-	idx, ok := arg["Input"].(int)
-	if !ok {
-		t.Fatalf("input index is not int: %T", arg["Input"])
-	}
-	input := tx.GetData().Inputs[idx]
-	if k, _ := input["$kind"].(string); k != "Pure" {
-		t.Fatalf("input is not Pure: %v", k)
-	}
-	pure := input["Pure"].(map[string]any)
-	b64 := pure["bytes"].(string)
-	data, err := base64.StdEncoding.DecodeString(b64)
-	if err != nil {
-		t.Fatalf("decode pure bytes failed: %v", err)
+	input := tx.GetData().Inputs[arg.Index]
+	if input.Pure == nil {
+		t.Fatalf("input is not Pure: %v", input.Kind())
 	}
-	return data
+	return input.Pure.Bytes
 }
 
 func readU64FromArg(t *testing.T, tx *stx.Transaction, arg stx.Argument) uint64 {
@@ -55,13 +55,17 @@ func (c *GovernanceContract) SubmitProposal(tx *stx.Transaction, params types.Pr
 	}, []string{base.Type, quote.Type})
 }
 
-func (c *GovernanceContract) Vote(tx *stx.Transaction, poolKey, balanceManagerKey, proposalID string) stx.Argument {
+func (c *GovernanceContract) Vote(tx *stx.Transaction, poolKey, balanceManagerKey, proposalID string) (stx.Argument, error) {
+	id, err := pureU128String(tx, proposalID)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	pool := c.config.GetPool(poolKey)
 	manager := c.config.GetBalanceManager(balanceManagerKey)
 	proof := c.balanceManager.GenerateProof(tx, balanceManagerKey)
 	base := c.config.GetCoin(pool.BaseCoin)
 	quote := c.config.GetCoin(pool.QuoteCoin)
 	return tx.MoveCall(c.config.DeepbookPackageID+"::pool::vote", []stx.Argument{
-		tx.Object(pool.Address), tx.Object(manager.Address), proof, pureU128String(tx, proposalID),
-	}, []string{base.Type, quote.Type})
+		tx.Object(pool.Address), tx.Object(manager.Address), proof, id,
+	}, []string{base.Type, quote.Type}), nil
 }
@@ -205,10 +205,14 @@ func (c *MarginRegistryContract) GetMarginPoolID(tx *stx.Transaction, coinKey st
 	}, []string{coin.Type})
 }
 
-func (c *MarginRegistryContract) GetMarginManagerIDs(tx *stx.Transaction, owner string) stx.Argument {
+func (c *MarginRegistryContract) GetMarginManagerIDs(tx *stx.Transaction, owner string) (stx.Argument, error) {
+	ownerArg, err := pureAddress(tx, owner)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	return tx.MoveCall(c.config.MarginPackageID+"::margin_registry::get_margin_manager_ids", []stx.Argument{
-		tx.PureBytes([]byte(owner)), tx.Object(c.config.MarginRegistryID),
-	}, nil)
+		ownerArg, tx.Object(c.config.MarginRegistryID),
+	}, nil), nil
 }
 
 func (c *MarginLiquidationsContract) CreateLiquidationVault(tx *stx.Transaction, liquidationAdminCap string) stx.Argument {
@@ -224,7 +228,11 @@ func (c *MarginLiquidationsContract) Balance(tx *stx.Transaction, vaultID, coinK
 	}, []string{coin.Type})
 }
 
-func (c *PoolProxyContract) PlaceLimitOrder(tx *stx.Transaction, params types.PlaceMarginLimitOrderParams) stx.Argument {
+func (c *PoolProxyContract) PlaceLimitOrder(tx *stx.Transaction, params types.PlaceMarginLimitOrderParams) (stx.Argument, error) {
+	clientOrderID, err := parseU64(params.ClientOrderID)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	manager := c.config.GetMarginManager(params.MarginManagerKey)
 	pool := c.config.GetPool(manager.PoolKey)
 	base := c.config.GetCoin(pool.BaseCoin)
@@ -237,7 +245,7 @@ func (c *PoolProxyContract) PlaceLimitOrder(tx *stx.Transaction, params types.Pl
 	}
 	return tx.MoveCall(c.config.MarginPackageID+"::pool_proxy::place_limit_order", []stx.Argument{
 		tx.Object(manager.Address),
-		pureU64(tx, parseU64(params.ClientOrderID)),
+		pureU64(tx, clientOrderID),
 		pureU8(tx, uint8(params.OrderType)),
 		pureU8(tx, uint8(params.SelfMatchingOption)),
 		pureU64(tx, price),
@@ -247,10 +255,14 @@ func (c *PoolProxyContract) PlaceLimitOrder(tx *stx.Transaction, params types.Pl
 		pureU64(tx, exp),
 		tx.Object(c.config.MarginRegistryID),
 		tx.Object("0x6"),
-	}, []string{base.Type, quote.Type})
+	}, []string{base.Type, quote.Type}), nil
 }
 
-func (c *PoolProxyContract) PlaceMarketOrder(tx *stx.Transaction, params types.PlaceMarginMarketOrderParams) stx.Argument {
+func (c *PoolProxyContract) PlaceMarketOrder(tx *stx.Transaction, params types.PlaceMarginMarketOrderParams) (stx.Argument, error) {
+	clientOrderID, err := parseU64(params.ClientOrderID)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	manager := c.config.GetMarginManager(params.MarginManagerKey)
 	pool := c.config.GetPool(manager.PoolKey)
 	base := c.config.GetCoin(pool.BaseCoin)
@@ -258,34 +270,42 @@ func (c *PoolProxyContract) PlaceMarketOrder(tx *stx.Transaction, params types.P
 	quantity := uint64(math.Round(params.Quantity * base.Scalar))
 	return tx.MoveCall(c.config.MarginPackageID+"::pool_proxy::place_market_order", []stx.Argument{
 		tx.Object(manager.Address),
-		pureU64(tx, parseU64(params.ClientOrderID)),
+		pureU64(tx, clientOrderID),
 		pureU8(tx, uint8(params.SelfMatchingOption)),
 		pureU64(tx, quantity),
 		pureBool(tx, params.IsBid),
 		pureBool(tx, params.PayWithDeep),
 		tx.Object(c.config.MarginRegistryID),
 		tx.Object("0x6"),
-	}, []string{base.Type, quote.Type})
+	}, []string{base.Type, quote.Type}), nil
 }
 
-func (c *PoolProxyContract) CancelOrder(tx *stx.Transaction, marginManagerKey, orderID string) stx.Argument {
+func (c *PoolProxyContract) CancelOrder(tx *stx.Transaction, marginManagerKey, orderID string) (stx.Argument, error) {
+	id, err := pureU128String(tx, orderID)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	manager := c.config.GetMarginManager(marginManagerKey)
 	pool := c.config.GetPool(manager.PoolKey)
 	base := c.config.GetCoin(pool.BaseCoin)
 	quote := c.config.GetCoin(pool.QuoteCoin)
 	return tx.MoveCall(c.config.MarginPackageID+"::pool_proxy::cancel_order", []stx.Argument{
-		tx.Object(manager.Address), pureU128String(tx, orderID), tx.Object(c.config.MarginRegistryID), tx.Object("0x6"),
-	}, []string{base.Type, quote.Type})
+		tx.Object(manager.Address), id, tx.Object(c.config.MarginRegistryID), tx.Object("0x6"),
+	}, []string{base.Type, quote.Type}), nil
 }
 
-func (c *PoolProxyContract) CancelOrders(tx *stx.Transaction, marginManagerKey string, orderIDs []string) stx.Argument {
+func (c *PoolProxyContract) CancelOrders(tx *stx.Transaction, marginManagerKey string, orderIDs []string) (stx.Argument, error) {
+	ids, err := pureVecU128(tx, orderIDs)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	manager := c.config.GetMarginManager(marginManagerKey)
 	pool := c.config.GetPool(manager.PoolKey)
 	base := c.config.GetCoin(pool.BaseCoin)
 	quote := c.config.GetCoin(pool.QuoteCoin)
 	return tx.MoveCall(c.config.MarginPackageID+"::pool_proxy::cancel_orders", []stx.Argument{
-		tx.Object(manager.Address), pureVecU128(tx, orderIDs), tx.Object(c.config.MarginRegistryID), tx.Object("0x6"),
-	}, []string{base.Type, quote.Type})
+		tx.Object(manager.Address), ids, tx.Object(c.config.MarginRegistryID), tx.Object("0x6"),
+	}, []string{base.Type, quote.Type}), nil
 }
 
 func (c *PoolProxyContract) CancelAllOrders(tx *stx.Transaction, marginManagerKey string) stx.Argument {
@@ -329,14 +349,18 @@ func (c *PoolProxyContract) Unstake(tx *stx.Transaction, marginManagerKey string
 	}, []string{base.Type, quote.Type})
 }
 
-func (c *PoolProxyContract) Vote(tx *stx.Transaction, marginManagerKey, proposalID string) stx.Argument {
+func (c *PoolProxyContract) Vote(tx *stx.Transaction, marginManagerKey, proposalID string) (stx.Argument, error) {
+	id, err := pureU128String(tx, proposalID)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	manager := c.config.GetMarginManager(marginManagerKey)
 	pool := c.config.GetPool(manager.PoolKey)
 	base := c.config.GetCoin(pool.BaseCoin)
 	quote := c.config.GetCoin(pool.QuoteCoin)
 	return tx.MoveCall(c.config.MarginPackageID+"::pool_proxy::vote", []stx.Argument{
-		tx.Object(manager.Address), pureU128String(tx, proposalID), tx.Object(c.config.MarginRegistryID),
-	}, []string{base.Type, quote.Type})
+		tx.Object(manager.Address), id, tx.Object(c.config.MarginRegistryID),
+	}, []string{base.Type, quote.Type}), nil
 }
 
 func (c *PoolProxyContract) ClaimRebate(tx *stx.Transaction, marginManagerKey string) stx.Argument {
@@ -349,15 +373,19 @@ func (c *PoolProxyContract) ClaimRebate(tx *stx.Transaction, marginManagerKey st
 	}, []string{base.Type, quote.Type})
 }
 
-func (c *MarginTPSLContract) AddConditionalOrder(tx *stx.Transaction, params types.AddConditionalOrderParams) stx.Argument {
+func (c *MarginTPSLContract) AddConditionalOrder(tx *stx.Transaction, params types.AddConditionalOrderParams) (stx.Argument, error) {
+	id, err := pureU128String(tx, params.ConditionalOrderID)
+	if err != nil {
+		return stx.Argument{}, err
+	}
 	manager := c.config.GetMarginManager(params.MarginManagerKey)
 	pool := c.config.GetPool(manager.PoolKey)
 	base := c.config.GetCoin(pool.BaseCoin)
 	quote := c.config.GetCoin(pool.QuoteCoin)
 	triggerPrice := uint64(math.Round((params.TriggerPrice * utils.FloatScalar * quote.Scalar) / base.Scalar))
 	return tx.MoveCall(c.config.MarginPackageID+"::margin_manager::add_conditional_order", []stx.Argument{
-		tx.Object(manager.Address), pureU128String(tx, params.ConditionalOrderID), pureBool(tx, params.TriggerBelowPrice), pureU64(tx, triggerPrice), tx.Object(c.config.MarginRegistryID), tx.Object("0x6"),
-	}, []string{base.Type, quote.Type})
+		tx.Object(manager.Address), id, pureBool(tx, params.TriggerBelowPrice), pureU64(tx, triggerPrice), tx.Object(c.config.MarginRegistryID), tx.Object("0x6"),
+	}, []string{base.Type, quote.Type}), nil
 }
 
 func (c *MarginTPSLContract) CancelAllConditionalOrders(tx *stx.Transaction, marginManagerKey string) stx.Argument {
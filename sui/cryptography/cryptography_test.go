@@ -1,7 +1,9 @@
 package cryptography
 
 import (
+	"bytes"
 	"encoding/base64"
+	"encoding/hex"
 	"testing"
 )
 
@@ -52,3 +54,61 @@ func TestSerializeSignature(t *testing.T) {
 		t.Fatalf("public key size mismatch")
 	}
 }
+
+func TestBlake2b(t *testing.T) {
+	long := bytes.Repeat(func() []byte {
+		b := make([]byte, 256)
+		for i := range b {
+			b[i] = byte(i)
+		}
+		return b
+	}(), 2)
+	cases := []struct {
+		size int
+		data []byte
+		want string
+	}{
+		{32, nil, "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8"},
+		{64, []byte("abc"), "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"},
+		{32, long, "540b20132d8aeae54057cb69c24f95d26a1c472cc700dd450defe9bb796d4f14"},
+		{20, make([]byte, 128), "8d26f158f564e3293b42f5e3d34263cb173aa9c9"},
+	}
+	for _, c := range cases {
+		h, err := NewBlake2b(c.size)
+		if err != nil {
+			t.Fatalf("new blake2b failed: %v", err)
+		}
+		// Feed in uneven chunks to exercise block buffering.
+		for rest := c.data; len(rest) > 0; {
+			n := min(len(rest), 77)
+			h.Write(rest[:n])
+			rest = rest[n:]
+		}
+		if got := hex.EncodeToString(h.Sum(nil)); got != c.want {
+			t.Fatalf("blake2b-%d: got %s want %s", c.size*8, got, c.want)
+		}
+	}
+	if got := Blake2b256(long[:100], long[100:]); hex.EncodeToString(got[:]) != cases[2].want {
+		t.Fatalf("Blake2b256 mismatch: %x", got)
+	}
+	if _, err := NewBlake2b(65); err == nil {
+		t.Fatalf("expected error for oversized digest")
+	}
+}
+
+func TestMessageWithIntent(t *testing.T) {
+	msg := []byte{0xaa, 0xbb}
+	if got := MessageWithIntent(IntentTransactionData, msg); !bytes.Equal(got, []byte{0, 0, 0, 0xaa, 0xbb}) {
+		t.Fatalf("transaction intent message: %x", got)
+	}
+	if got := MessageWithIntent(IntentPersonalMessage, msg); !bytes.Equal(got, []byte{3, 0, 0, 0xaa, 0xbb}) {
+		t.Fatalf("personal message intent message: %x", got)
+	}
+	if got := PersonalMessageBytes(bytes.Repeat([]byte{1}, 200)); !bytes.Equal(got[:2], []byte{0xc8, 0x01}) || len(got) != 202 {
+		t.Fatalf("personal message must be prefixed with its ULEB128 length, got %x", got[:3])
+	}
+	digest := IntentMessageDigest(IntentTransactionData, msg)
+	if want := Blake2b256([]byte{0, 0, 0, 0xaa, 0xbb}); digest != want {
+		t.Fatalf("expected the Blake2b-256 digest of the intent message")
+	}
+}
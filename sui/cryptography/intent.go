@@ -1,8 +1,7 @@
 package cryptography
 
 import (
-	"bytes"
-	"encoding/binary"
+	"github.com/sui-sdks/go-sdks/bcs"
 )
 
 type IntentScope string
@@ -12,8 +11,9 @@ const (
 	IntentPersonalMessage IntentScope = "PersonalMessage"
 )
 
+// MessageWithIntent prefixes message with its 3-byte intent: the scope, the
+// intent version (V0) and the app ID (Sui).
 func MessageWithIntent(scope IntentScope, message []byte) []byte {
-	// A compact Go-friendly intent prefix, preserving domain separation semantics.
 	var scopeFlag byte
 	switch scope {
 	case IntentTransactionData:
@@ -23,13 +23,19 @@ func MessageWithIntent(scope IntentScope, message []byte) []byte {
 	default:
 		scopeFlag = 255
 	}
-	buf := bytes.NewBuffer(make([]byte, 0, len(message)+8))
-	buf.WriteByte(scopeFlag)
-	buf.WriteByte(0) // version V0
-	buf.WriteByte(0) // app Sui
-	var ln [4]byte
-	binary.LittleEndian.PutUint32(ln[:], uint32(len(message)))
-	buf.Write(ln[:])
-	buf.Write(message)
-	return buf.Bytes()
+	out := make([]byte, 0, len(message)+3)
+	out = append(out, scopeFlag, 0, 0)
+	return append(out, message...)
+}
+
+// IntentMessageDigest returns the Blake2b-256 digest of message with its
+// intent, which is what Sui signatures sign.
+func IntentMessageDigest(scope IntentScope, message []byte) [32]byte {
+	return Blake2b256(MessageWithIntent(scope, message))
+}
+
+// PersonalMessageBytes BCS-encodes a personal message as vector<u8>, the form
+// in which it is signed.
+func PersonalMessageBytes(message []byte) []byte {
+	return append(bcs.ULEBEncode(uint64(len(message))), message...)
 }
@@ -1,7 +1,6 @@
 package cryptography
 
 import (
-	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
 	"strings"
@@ -39,8 +38,7 @@ type Keypair interface {
 }
 
 func SignWithIntent(signer Signer, bytes []byte, intent IntentScope) (SignatureWithBytes, error) {
-	intentMessage := MessageWithIntent(intent, bytes)
-	digest := sha256.Sum256(intentMessage)
+	digest := IntentMessageDigest(intent, bytes)
 	sig, err := signer.Sign(digest[:])
 	if err != nil {
 		return SignatureWithBytes{}, err
@@ -60,8 +58,15 @@ func SignTransaction(signer Signer, bytes []byte) (SignatureWithBytes, error) {
 	return SignWithIntent(signer, bytes, IntentTransactionData)
 }
 
+// SignPersonalMessage signs bytes BCS-encoded as vector<u8>. The returned
+// Bytes are the message itself.
 func SignPersonalMessage(signer Signer, bytes []byte) (SignatureWithBytes, error) {
-	return SignWithIntent(signer, bytes, IntentPersonalMessage)
+	sig, err := SignWithIntent(signer, PersonalMessageBytes(bytes), IntentPersonalMessage)
+	if err != nil {
+		return SignatureWithBytes{}, err
+	}
+	sig.Bytes = base64.StdEncoding.EncodeToString(bytes)
+	return sig, nil
 }
 
 func DecodeSuiPrivateKey(value string) (ParsedKeypair, error) {
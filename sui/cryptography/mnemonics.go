@@ -0,0 +1,163 @@
+package cryptography
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// BIP-39 mnemonics with the English wordlist, and the derivation paths used
+// by Sui wallets.
+
+//go:embed english.txt
+var englishWords string
+
+var (
+	wordlist  = strings.Fields(englishWords)
+	wordIndex = func() map[string]int {
+		m := make(map[string]int, len(wordlist))
+		for i, w := range wordlist {
+			m[w] = i
+		}
+		return m
+	}()
+)
+
+// HardenedOffset is added to the index of a hardened derivation path segment.
+const HardenedOffset uint32 = 0x80000000
+
+var (
+	hardenedPathPattern = regexp.MustCompile(`^m/44'/784'/[0-9]+'/[0-9]+'/[0-9]+'$`)
+	bip32PathPattern    = regexp.MustCompile(`^m/(54|74)'/784'/[0-9]+'/[0-9]+/[0-9]+$`)
+)
+
+// IsValidHardenedPath reports whether path is an Ed25519 path of the form
+// m/44'/784'/{account}'/{change}'/{address}'.
+func IsValidHardenedPath(path string) bool {
+	return hardenedPathPattern.MatchString(path)
+}
+
+// IsValidBIP32Path reports whether path is a Secp256k1 (purpose 54) or
+// Secp256r1 (purpose 74) path of the form m/{purpose}'/784'/{account}'/{change}/{address}.
+func IsValidBIP32Path(path string) bool {
+	return bip32PathPattern.MatchString(path)
+}
+
+// ParseDerivationPath returns the indexes of a path such as m/44'/784'/0',
+// with HardenedOffset added to the hardened ones.
+func ParseDerivationPath(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if segments[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path: %s", path)
+	}
+	out := make([]uint32, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		hardened := strings.HasSuffix(segment, "'")
+		index, err := strconv.ParseUint(strings.TrimSuffix(segment, "'"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path: %s", path)
+		}
+		if hardened {
+			index += uint64(HardenedOffset)
+		}
+		out = append(out, uint32(index))
+	}
+	return out, nil
+}
+
+// GenerateMnemonic returns a random mnemonic for entropyBits of entropy,
+// which must be a multiple of 32 from 128 (12 words) to 256 (24 words).
+func GenerateMnemonic(entropyBits int) (string, error) {
+	if entropyBits%32 != 0 || entropyBits < 128 || entropyBits > 256 {
+		return "", fmt.Errorf("invalid entropy size: %d bits", entropyBits)
+	}
+	entropy := make([]byte, entropyBits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return EntropyToMnemonic(entropy)
+}
+
+// EntropyToMnemonic encodes entropy and its SHA-256 checksum as words.
+func EntropyToMnemonic(entropy []byte) (string, error) {
+	if len(entropy)%4 != 0 || len(entropy) < 16 || len(entropy) > 32 {
+		return "", fmt.Errorf("invalid entropy size: %d bytes", len(entropy))
+	}
+	checksum := sha256.Sum256(entropy)
+	data := append(append([]byte(nil), entropy...), checksum[0])
+	words := make([]string, len(entropy)*3/4)
+	for i := range words {
+		var index int
+		for bit := i * 11; bit < (i+1)*11; bit++ {
+			index = index<<1 | int(data[bit/8]>>(7-bit%8)&1)
+		}
+		words[i] = wordlist[index]
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy decodes a mnemonic, verifying its word count, words and
+// checksum.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := normalizeMnemonic(mnemonic)
+	if len(words)%3 != 0 || len(words) < 12 || len(words) > 24 {
+		return nil, fmt.Errorf("invalid mnemonic length: %d words", len(words))
+	}
+	data := make([]byte, (len(words)*11+7)/8)
+	for i, w := range words {
+		index, ok := wordIndex[w]
+		if !ok {
+			return nil, fmt.Errorf("invalid mnemonic word: %q", w)
+		}
+		for j := 0; j < 11; j++ {
+			bit := i*11 + j
+			data[bit/8] |= byte(index>>(10-j)&1) << (7 - bit%8)
+		}
+	}
+	entropy := data[:len(words)*4/3]
+	checksumBits := len(words) / 3
+	checksum := sha256.Sum256(entropy)
+	if data[len(entropy)]>>(8-checksumBits) != checksum[0]>>(8-checksumBits) {
+		return nil, errors.New("invalid mnemonic checksum")
+	}
+	return entropy, nil
+}
+
+// ValidateMnemonic reports why mnemonic is not a valid BIP-39 mnemonic.
+func ValidateMnemonic(mnemonic string) error {
+	_, err := MnemonicToEntropy(mnemonic)
+	return err
+}
+
+// MnemonicToSeed validates mnemonic and derives its 64-byte BIP-39 seed.
+// A non-ASCII passphrase must already be NFKD normalized.
+func MnemonicToSeed(mnemonic, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	normalized := strings.Join(normalizeMnemonic(mnemonic), " ")
+	return pbkdf2.Key(sha512.New, normalized, []byte("mnemonic"+passphrase), 2048, 64)
+}
+
+// MnemonicToSeedHex is MnemonicToSeed with an empty passphrase, hex encoded.
+func MnemonicToSeedHex(mnemonic string) (string, error) {
+	seed, err := MnemonicToSeed(mnemonic, "")
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(seed), nil
+}
+
+// normalizeMnemonic splits mnemonic into lower case words, as wallets accept
+// extra whitespace and capitals.
+func normalizeMnemonic(mnemonic string) []string {
+	return strings.Fields(strings.ToLower(mnemonic))
+}
@@ -0,0 +1,84 @@
+package cryptography
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestMnemonicVectors(t *testing.T) {
+	// BIP-39 reference vector with the passphrase "TREZOR".
+	mnemonic, err := EntropyToMnemonic(make([]byte, 16))
+	if err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+	if mnemonic != strings.Repeat("abandon ", 11)+"about" {
+		t.Fatalf("unexpected mnemonic %q", mnemonic)
+	}
+	seed, err := MnemonicToSeed(mnemonic, "TREZOR")
+	if err != nil {
+		t.Fatalf("seed failed: %v", err)
+	}
+	if got := hex.EncodeToString(seed); got != "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04" {
+		t.Fatalf("seed mismatch: %s", got)
+	}
+	// Whitespace and case are normalized.
+	if again, _ := MnemonicToSeed("  "+strings.ToUpper(mnemonic)+"\n", "TREZOR"); hex.EncodeToString(again) != hex.EncodeToString(seed) {
+		t.Fatalf("expected a normalized mnemonic to give the same seed")
+	}
+}
+
+func TestGenerateAndValidateMnemonic(t *testing.T) {
+	for _, bits := range []int{128, 256} {
+		mnemonic, err := GenerateMnemonic(bits)
+		if err != nil {
+			t.Fatalf("generate failed: %v", err)
+		}
+		words := strings.Fields(mnemonic)
+		if len(words) != bits/32*3 {
+			t.Fatalf("expected %d words, got %d", bits/32*3, len(words))
+		}
+		entropy, err := MnemonicToEntropy(mnemonic)
+		if err != nil || len(entropy) != bits/8 {
+			t.Fatalf("round trip failed: %v", err)
+		}
+	}
+	if _, err := GenerateMnemonic(100); err == nil {
+		t.Fatalf("expected invalid entropy size to fail")
+	}
+
+	valid := strings.Repeat("abandon ", 11) + "about"
+	for _, invalid := range []string{
+		strings.Repeat("abandon ", 12),          // bad checksum
+		strings.Repeat("abandon ", 11) + "abou", // unknown word
+		strings.Repeat("abandon ", 8) + "about", // bad length
+	} {
+		if err := ValidateMnemonic(invalid); err == nil {
+			t.Fatalf("expected %q to be invalid", invalid)
+		}
+	}
+	if err := ValidateMnemonic(valid); err != nil {
+		t.Fatalf("expected valid mnemonic: %v", err)
+	}
+}
+
+func TestDerivationPaths(t *testing.T) {
+	if !IsValidHardenedPath("m/44'/784'/0'/0'/0'") || IsValidHardenedPath("m/44'/784'/0'/0/0") {
+		t.Fatalf("hardened path validation mismatch")
+	}
+	if !IsValidBIP32Path("m/54'/784'/0'/0/0") || !IsValidBIP32Path("m/74'/784'/1'/0/3") || IsValidBIP32Path("m/44'/784'/0'/0/0") {
+		t.Fatalf("bip32 path validation mismatch")
+	}
+	indexes, err := ParseDerivationPath("m/44'/784'/2")
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if len(indexes) != 3 || indexes[0] != HardenedOffset+44 || indexes[1] != HardenedOffset+784 || indexes[2] != 2 {
+		t.Fatalf("unexpected indexes %v", indexes)
+	}
+	for _, invalid := range []string{"44'/0", "m/x", "m/2147483648"} {
+		if _, err := ParseDerivationPath(invalid); err == nil {
+			t.Fatalf("expected %q to be rejected", invalid)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package cryptography
 
 import (
-	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
@@ -46,18 +45,17 @@ func ToSuiPublicKey(pk PublicKey) string {
 }
 
 func ToSuiAddress(pk PublicKey) string {
-	digest := sha256.Sum256(ToSuiBytes(pk))
+	digest := Blake2b256(ToSuiBytes(pk))
 	return utils.NormalizeSuiAddress(hex.EncodeToString(digest[:])[:utils.SuiAddressLength*2])
 }
 
 func VerifyWithIntent(pk PublicKey, bytes, signature []byte, intent IntentScope) bool {
-	msg := MessageWithIntent(intent, bytes)
-	digest := sha256.Sum256(msg)
+	digest := IntentMessageDigest(intent, bytes)
 	return pk.Verify(digest[:], signature)
 }
 
 func VerifyPersonalMessage(pk PublicKey, msg, signature []byte) bool {
-	return VerifyWithIntent(pk, msg, signature, IntentPersonalMessage)
+	return VerifyWithIntent(pk, PersonalMessageBytes(msg), signature, IntentPersonalMessage)
 }
 
 func VerifyTransaction(pk PublicKey, tx, signature []byte) bool {
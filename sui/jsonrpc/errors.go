@@ -22,6 +22,10 @@ func (e *HTTPTransportError) Error() string {
 	return "sui http transport error: " + e.Cause.Error()
 }
 
+func (e *HTTPTransportError) Unwrap() error {
+	return e.Cause
+}
+
 type JsonRPCError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
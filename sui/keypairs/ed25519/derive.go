@@ -0,0 +1,67 @@
+package ed25519
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/sui-sdks/go-sdks/sui/cryptography"
+)
+
+// DeriveKeypair derives the keypair at path from a BIP-39 mnemonic, as Sui
+// wallets do. An empty path means DefaultDerivationPath.
+func DeriveKeypair(mnemonic, path string) (*Keypair, error) {
+	seed, err := cryptography.MnemonicToSeed(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	return DeriveKeypairFromSeed(seed, path)
+}
+
+// DeriveKeypairFromSeed derives the keypair at path from a BIP-39 seed. An
+// empty path means DefaultDerivationPath.
+func DeriveKeypairFromSeed(seed []byte, path string) (*Keypair, error) {
+	if path == "" {
+		path = DefaultDerivationPath
+	}
+	if !cryptography.IsValidHardenedPath(path) {
+		return nil, fmt.Errorf("invalid derivation path: %s", path)
+	}
+	key, err := DeriveSecretKey(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return FromSecretKey(key)
+}
+
+// DeriveSecretKey derives the private key at path from seed with SLIP-0010,
+// which only supports hardened segments for Ed25519.
+func DeriveSecretKey(seed []byte, path string) ([]byte, error) {
+	indexes, err := cryptography.ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	key, chainCode := slip10(seed)
+	for _, index := range indexes {
+		if index < cryptography.HardenedOffset {
+			return nil, fmt.Errorf("ed25519 derivation path segments must be hardened: %s", path)
+		}
+		data := make([]byte, 0, 37)
+		data = append(append(append(data, 0), key...), 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(data[33:], index)
+		key, chainCode = hmacSHA512(chainCode, data)
+	}
+	return key, nil
+}
+
+func slip10(seed []byte) (key, chainCode []byte) {
+	return hmacSHA512([]byte("ed25519 seed"), seed)
+}
+
+func hmacSHA512(key, data []byte) ([]byte, []byte) {
+	m := hmac.New(sha512.New, key)
+	m.Write(data)
+	sum := m.Sum(nil)
+	return sum[:32], sum[32:]
+}
@@ -1,7 +1,16 @@
 package ed25519
 
 import (
+	"bytes"
+	cryptoed25519 "crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/sui-sdks/go-sdks/sui/cryptography"
 )
 
 func TestEd25519SignVerify(t *testing.T) {
@@ -30,3 +39,153 @@ func TestEd25519SignVerify(t *testing.T) {
 		t.Fatalf("restored verify failed")
 	}
 }
+
+// keytoolSeed is the ED25519 key suiprivkey1qqqscjyyr64jea849dfv9cukurqj2swx0m3rr4hr7sw955jy07tzgcde5ut,
+// whose address was produced by `sui keytool`.
+const keytoolSeed = "010c48841eab2cf4f52b52c2e396e0c12541c67ee231d6e3f41c5a52447f9624"
+
+func TestEd25519KeytoolAddress(t *testing.T) {
+	seed, _ := hex.DecodeString(keytoolSeed)
+	kp, err := FromSecretKey(seed)
+	if err != nil {
+		t.Fatalf("from secret key failed: %v", err)
+	}
+	if got, want := kp.ToSuiAddress(), "0xe69e896ca10f5a77732769803cc2b5707f0ab9d4407afb5e4b4464b89769af14"; got != want {
+		t.Fatalf("address mismatch: got %s want %s", got, want)
+	}
+}
+
+func TestEd25519SignsIntentDigest(t *testing.T) {
+	seed, _ := hex.DecodeString(keytoolSeed)
+	kp, _ := FromSecretKey(seed)
+	pub := cryptoed25519.PublicKey(kp.GetPublicKey().ToRawBytes())
+	txBytes := []byte{0, 1, 2, 3}
+	message := []byte("hello")
+
+	cases := []struct {
+		sign   func([]byte) (cryptography.SignatureWithBytes, error)
+		data   []byte
+		signed []byte
+	}{
+		// Sui signs the Blake2b-256 digest of the 3-byte intent followed by
+		// the transaction bytes, or the message BCS-encoded as vector<u8>.
+		{kp.SignTransaction, txBytes, append([]byte{0, 0, 0}, txBytes...)},
+		{kp.SignPersonalMessage, message, append([]byte{3, 0, 0, byte(len(message))}, message...)},
+	}
+	for _, c := range cases {
+		sig, err := c.sign(c.data)
+		if err != nil {
+			t.Fatalf("sign failed: %v", err)
+		}
+		if sig.Bytes != base64.StdEncoding.EncodeToString(c.data) {
+			t.Fatalf("expected the signed bytes to be returned, got %s", sig.Bytes)
+		}
+		parsed, err := cryptography.ParseSerializedSignature(sig.Signature)
+		if err != nil {
+			t.Fatalf("parse signature failed: %v", err)
+		}
+		digest := cryptography.Blake2b256(c.signed)
+		if !cryptoed25519.Verify(pub, digest[:], parsed.Signature) {
+			t.Fatalf("signature is not over the intent message digest")
+		}
+	}
+	sig, _ := kp.SignPersonalMessage(message)
+	parsed, _ := cryptography.ParseSerializedSignature(sig.Signature)
+	if !cryptography.VerifyPersonalMessage(kp.GetPublicKey(), message, parsed.Signature) {
+		t.Fatalf("personal message verification failed")
+	}
+	if cryptography.VerifyTransaction(kp.GetPublicKey(), message, parsed.Signature) {
+		t.Fatalf("a personal message signature must not verify as a transaction")
+	}
+}
+
+const testMnemonic = "film crazy soon outside stand loop subway crumble thrive popular green nuclear struggle pistol arm wife phrase warfare march wheat nephew ask sunny firm"
+
+// The vector is shared with the TS SDK.
+func TestEd25519DeriveKeypair(t *testing.T) {
+	kp, err := DeriveKeypair(testMnemonic, "")
+	if err != nil {
+		t.Fatalf("derive failed: %v", err)
+	}
+	if got := base64.StdEncoding.EncodeToString(kp.GetPublicKey().ToRawBytes()); got != "ImR/7u82MGC9QgWhZxoV8QoSNnZZGLG19jjYLzPPxGk=" {
+		t.Fatalf("public key mismatch: %s", got)
+	}
+	if got := kp.ToSuiAddress(); got != "0xa2d14fad60c56049ecf75246a481934691214ce413e6a8ae2fe6834c173a6133" {
+		t.Fatalf("address mismatch: %s", got)
+	}
+	if _, err := DeriveKeypair(testMnemonic, "m/44'/784'/0'/0/0"); err == nil {
+		t.Fatalf("expected a non-hardened path to be rejected")
+	}
+}
+
+// SLIP-0010 test vector 1 for ed25519.
+func TestSLIP10Vectors(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	cases := map[string]string{
+		"m":       "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+		"m/0'":    "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+		"m/0'/1'": "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2",
+	}
+	for path, want := range cases {
+		key, err := DeriveSecretKey(seed, path)
+		if err != nil {
+			t.Fatalf("derive %s failed: %v", path, err)
+		}
+		if got := hex.EncodeToString(key); got != want {
+			t.Fatalf("key at %s mismatch: %s", path, got)
+		}
+	}
+}
+
+// keytoolSignaturesFile holds the --json output of `sui keytool sign` for
+// the keytool key, once with the transaction intent and once with the
+// personal message intent:
+//
+//	sui keytool import suiprivkey1qqqscjyyr64jea849dfv9cukurqj2swx0m3rr4hr7sw955jy07tzgcde5ut ed25519
+//	sui keytool sign --address 0xe69e896ca10f5a77732769803cc2b5707f0ab9d4407afb5e4b4464b89769af14 \
+//	  --data <base64 TransactionData> --intent 000000 --json
+//	sui keytool sign --address 0xe69e896ca10f5a77732769803cc2b5707f0ab9d4407afb5e4b4464b89769af14 \
+//	  --data <base64 TransactionData> --intent 030000 --json
+var keytoolSignaturesFile = filepath.Join("testdata", "keytool_signatures.json")
+
+type keytoolSignature struct {
+	SuiAddress   string `json:"suiAddress"`
+	RawTxData    string `json:"rawTxData"`
+	RawIntentMsg string `json:"rawIntentMsg"`
+	SuiSignature string `json:"suiSignature"`
+}
+
+func TestEd25519KeytoolSignatures(t *testing.T) {
+	data, err := os.ReadFile(keytoolSignaturesFile)
+	if err != nil {
+		t.Fatalf("read keytool signatures failed: %v", err)
+	}
+	var sigs []keytoolSignature
+	if err := json.Unmarshal(data, &sigs); err != nil {
+		t.Fatalf("unmarshal keytool signatures failed: %v", err)
+	}
+	if len(sigs) == 0 {
+		t.Skipf("%s has no signatures yet; see keytoolSignaturesFile for the commands", keytoolSignaturesFile)
+	}
+	seed, _ := hex.DecodeString(keytoolSeed)
+	kp, _ := FromSecretKey(seed)
+	scopes := map[byte]cryptography.IntentScope{0: cryptography.IntentTransactionData, 3: cryptography.IntentPersonalMessage}
+	for _, s := range sigs {
+		if s.SuiAddress != kp.ToSuiAddress() {
+			t.Fatalf("signature is for %s, not the keytool key", s.SuiAddress)
+		}
+		txData, _ := base64.StdEncoding.DecodeString(s.RawTxData)
+		intentMsg, _ := base64.StdEncoding.DecodeString(s.RawIntentMsg)
+		if len(intentMsg) < 3 || !bytes.Equal(intentMsg[3:], txData) {
+			t.Fatalf("intent message is not the intent followed by the transaction data: %s", s.RawIntentMsg)
+		}
+		scope, ok := scopes[intentMsg[0]]
+		if !ok {
+			t.Fatalf("unexpected intent scope %d", intentMsg[0])
+		}
+		sig, err := kp.SignWithIntent(txData, scope)
+		if err != nil || sig.Signature != s.SuiSignature {
+			t.Fatalf("%s signature mismatch: got %s want %s (%v)", scope, sig.Signature, s.SuiSignature, err)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package ecc
+
+import (
+	"encoding/hex"
+	"errors"
+)
+
+// Curve is a short Weierstrass curve y^2 = x^3 + ax + b with a base point of
+// 256-bit prime order, over a prime field with p = 3 mod 4.
+type Curve struct {
+	// P is the base field and N the scalar field, modulo the order of G.
+	P, N *Field
+	A, B Element
+	// G is the base point.
+	G Point
+	// SqrtExp is (p+1)/4, so a^SqrtExp is a square root of a when a is a
+	// square.
+	SqrtExp []byte
+	// Add returns p+q with a complete formula for the curve's a, which also
+	// handles doubling and the identity without branches.
+	Add func(p, q Point) Point
+}
+
+// Point is a curve point in projective coordinates (X:Y:Z), with x = X/Z and
+// y = Y/Z. The identity is (0:1:0).
+type Point struct {
+	X, Y, Z Element
+}
+
+// MustHex decodes a hex constant.
+func MustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (c *Curve) identity() Point {
+	return Point{X: c.P.Zero(), Y: c.P.One(), Z: c.P.Zero()}
+}
+
+// IsIdentity reports whether p is the point at infinity.
+func (c *Curve) IsIdentity(p Point) bool {
+	return c.P.IsZero(p.Z)
+}
+
+func (c *Curve) selectPoint(s uint64, a, b Point) Point {
+	return Point{X: c.P.Select(s, a.X, b.X), Y: c.P.Select(s, a.Y, b.Y), Z: c.P.Select(s, a.Z, b.Z)}
+}
+
+// ScalarMult returns k*p for a 32-byte big-endian scalar, doubling and adding
+// for every bit so the time taken does not depend on k.
+func (c *Curve) ScalarMult(k []byte, p Point) Point {
+	r := c.identity()
+	for _, byt := range k {
+		for i := 7; i >= 0; i-- {
+			r = c.Add(r, r)
+			r = c.selectPoint(uint64(byt>>i&1), c.Add(r, p), r)
+		}
+	}
+	return r
+}
+
+// ScalarBaseMult returns k*G.
+func (c *Curve) ScalarBaseMult(k []byte) Point {
+	return c.ScalarMult(k, c.G)
+}
+
+// affine returns the affine coordinates of p, which must not be the identity.
+func (c *Curve) affine(p Point) (x, y Element) {
+	zInv := c.P.Inverse(p.Z)
+	return c.P.Mul(p.X, zInv), c.P.Mul(p.Y, zInv)
+}
+
+// Compress encodes p as a 33-byte SEC 1 compressed point.
+func (c *Curve) Compress(p Point) []byte {
+	x, y := c.affine(p)
+	return append([]byte{0x02 | byte(c.P.IsOdd(y))}, c.P.Bytes(x)...)
+}
+
+// Decompress parses a 33-byte SEC 1 compressed point, checking that it lies on
+// the curve.
+func (c *Curve) Decompress(data []byte) (Point, error) {
+	if len(data) != 33 || (data[0] != 0x02 && data[0] != 0x03) {
+		return Point{}, errors.New("invalid compressed point encoding")
+	}
+	x, ok := c.P.SetCanonicalBytes(data[1:])
+	if !ok {
+		return Point{}, errors.New("point x coordinate is not in the field")
+	}
+	// x^3 + ax + b
+	rhs := c.P.Add(c.P.Add(c.P.Mul(c.P.Square(x), x), c.P.Mul(c.A, x)), c.B)
+	y := c.P.Exp(rhs, c.SqrtExp)
+	if !c.P.Equal(c.P.Square(y), rhs) {
+		return Point{}, errors.New("point is not on the curve")
+	}
+	if c.P.IsOdd(y) != uint64(data[0]&1) {
+		y = c.P.Neg(y)
+	}
+	return Point{X: x, Y: y, Z: c.P.One()}, nil
+}
+
+// baseMultX returns the big-endian affine x coordinate of k*G for a scalar
+// 0 < k < n, in constant time.
+func (c *Curve) baseMultX(k []byte) []byte {
+	x, _ := c.affine(c.ScalarBaseMult(k))
+	return c.P.Bytes(x)
+}
+
+// verifyX reports whether the x coordinate of u1*G + u2*Q, reduced modulo n,
+// equals r, for a compressed public key q. It only handles public values.
+func (c *Curve) verifyX(u1, u2, q, r []byte) bool {
+	pub, err := c.Decompress(q)
+	if err != nil {
+		return false
+	}
+	sum := c.Add(c.ScalarBaseMult(u1), c.ScalarMult(u2, pub))
+	if c.IsIdentity(sum) {
+		return false
+	}
+	x, _ := c.affine(sum)
+	return c.N.Equal(c.N.SetBytes(c.P.Bytes(x)), c.N.SetBytes(r))
+}
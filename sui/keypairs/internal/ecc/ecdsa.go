@@ -0,0 +1,111 @@
+package ecc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+)
+
+// SignatureSize is the size of a compact r||s signature.
+const SignatureSize = 64
+
+// PrivateScalar parses a 32-byte private key, which must be in [1, n).
+func (c *Curve) PrivateScalar(d []byte) (Element, error) {
+	if len(d) != 32 {
+		return Element{}, errors.New("private key must be 32 bytes")
+	}
+	k, ok := c.N.SetCanonicalBytes(d)
+	if !ok || c.N.IsZero(k) {
+		return Element{}, errors.New("private key is not a valid scalar")
+	}
+	return k, nil
+}
+
+// Sign signs the 32-byte hash with the private key d using an RFC 6979
+// nonce derived with HMAC-SHA256, returning a low-s compact signature.
+func (c *Curve) Sign(d, hash []byte) ([]byte, error) {
+	x, err := c.PrivateScalar(d)
+	if err != nil {
+		return nil, err
+	}
+	if len(hash) != 32 {
+		return nil, errors.New("hash must be 32 bytes")
+	}
+	h := c.N.SetBytes(hash)
+	nonces := newRFC6979(d, c.N.Bytes(h))
+	for {
+		kBytes := nonces.next()
+		k, ok := c.N.SetCanonicalBytes(kBytes)
+		if !ok || c.N.IsZero(k) {
+			continue
+		}
+		r := c.N.SetBytes(c.baseMultX(kBytes))
+		if c.N.IsZero(r) {
+			continue
+		}
+		// s = k^-1 (h + r*d)
+		s := c.N.Mul(c.N.Inverse(k), c.N.Add(h, c.N.Mul(r, x)))
+		if c.N.IsZero(s) {
+			continue
+		}
+		s = c.N.Select(c.N.IsHigh(s), c.N.Neg(s), s)
+		return append(c.N.Bytes(r), c.N.Bytes(s)...), nil
+	}
+}
+
+// Verify reports whether sig is a valid low-s compact signature of the
+// 32-byte hash by the compressed public key q.
+func (c *Curve) Verify(q, hash, sig []byte) bool {
+	if len(sig) != SignatureSize || len(hash) != 32 {
+		return false
+	}
+	r, okR := c.N.SetCanonicalBytes(sig[:32])
+	s, okS := c.N.SetCanonicalBytes(sig[32:])
+	if !okR || !okS || c.N.IsZero(r) || c.N.IsZero(s) || c.N.IsHigh(s) == 1 {
+		return false
+	}
+	w := c.N.Inverse(s)
+	h := c.N.SetBytes(hash)
+	u1 := c.N.Mul(h, w)
+	u2 := c.N.Mul(r, w)
+	return c.verifyX(c.N.Bytes(u1), c.N.Bytes(u2), q, sig[:32])
+}
+
+// rfc6979 generates the candidate nonces of RFC 6979 section 3.2 for a
+// 256-bit order and HMAC-SHA256.
+type rfc6979 struct {
+	k, v  []byte
+	first bool
+}
+
+func newRFC6979(d, h []byte) *rfc6979 {
+	g := &rfc6979{k: make([]byte, 32), v: make([]byte, 32), first: true}
+	for i := range g.v {
+		g.v[i] = 0x01
+	}
+	g.k = g.mac(g.v, []byte{0x00}, d, h)
+	g.v = g.mac(g.v)
+	g.k = g.mac(g.v, []byte{0x01}, d, h)
+	g.v = g.mac(g.v)
+	return g
+}
+
+func (g *rfc6979) mac(parts ...[]byte) []byte {
+	m := hmac.New(sha256.New, g.k)
+	for _, p := range parts {
+		m.Write(p)
+	}
+	return m.Sum(nil)
+}
+
+// next returns the next candidate. Callers retry with the following one when
+// a candidate is out of range or yields a zero r or s.
+func (g *rfc6979) next() []byte {
+	if !g.first {
+		g.k = g.mac(g.v, []byte{0x00})
+		g.v = g.mac(g.v)
+	}
+	g.first = false
+	g.v = g.mac(g.v)
+	return append([]byte(nil), g.v...)
+}
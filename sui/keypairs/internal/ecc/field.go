@@ -0,0 +1,232 @@
+// Package ecc implements the constant-time arithmetic shared by the ECDSA
+// keypairs: Montgomery arithmetic modulo 256-bit primes, curve points and
+// RFC 6979 signing.
+package ecc
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+)
+
+// Element is an integer modulo a Field's modulus in Montgomery form, as
+// little-endian 64-bit limbs.
+type Element [4]uint64
+
+// Field is arithmetic modulo an odd prime below 2^256. Every operation runs in
+// time independent of the values of its operands.
+type Field struct {
+	m    [4]uint64
+	mInv uint64 // -m^-1 mod 2^64
+	r2   Element
+	one  Element
+}
+
+// NewField returns the field modulo the big-endian 32-byte prime m.
+func NewField(m []byte) *Field {
+	f := &Field{m: limbs(m)}
+	// Newton's iteration for m^-1 mod 2^64.
+	inv := uint64(1)
+	for i := 0; i < 6; i++ {
+		inv *= 2 - f.m[0]*inv
+	}
+	f.mInv = -inv
+	mod := new(big.Int).SetBytes(m)
+	r := new(big.Int).Lsh(big.NewInt(1), 256)
+	f.one = Element(limbs(fixed(new(big.Int).Mod(r, mod))))
+	f.r2 = Element(limbs(fixed(new(big.Int).Mod(new(big.Int).Mul(r, r), mod))))
+	return f
+}
+
+func limbs(b []byte) [4]uint64 {
+	var out [4]uint64
+	for i := 0; i < 4; i++ {
+		out[i] = binary.BigEndian.Uint64(b[24-8*i:])
+	}
+	return out
+}
+
+func fixed(n *big.Int) []byte {
+	return n.FillBytes(make([]byte, 32))
+}
+
+// Modulus returns the modulus as 32 big-endian bytes.
+func (f *Field) Modulus() []byte {
+	return f.toBytes(f.m)
+}
+
+func (f *Field) toBytes(v [4]uint64) []byte {
+	out := make([]byte, 32)
+	for i := 0; i < 4; i++ {
+		binary.BigEndian.PutUint64(out[24-8*i:], v[i])
+	}
+	return out
+}
+
+// SetBytes returns the 32-byte big-endian integer b reduced modulo m once,
+// which fully reduces it since every supported modulus exceeds 2^255.
+func (f *Field) SetBytes(b []byte) Element {
+	v := limbs(b)
+	reduced, borrow := sub4(v, f.m)
+	return f.Mul(Element(select4(borrow, v, reduced)), f.r2)
+}
+
+// SetCanonicalBytes is SetBytes, reporting whether b was below m.
+func (f *Field) SetCanonicalBytes(b []byte) (Element, bool) {
+	_, borrow := sub4(limbs(b), f.m)
+	return f.SetBytes(b), borrow == 1
+}
+
+// Bytes returns e as 32 big-endian bytes.
+func (f *Field) Bytes(e Element) []byte {
+	return f.toBytes(f.fromMont(e))
+}
+
+func (f *Field) fromMont(e Element) [4]uint64 {
+	return [4]uint64(f.Mul(e, Element{1}))
+}
+
+func (f *Field) One() Element  { return f.one }
+func (f *Field) Zero() Element { return Element{} }
+
+// sub4 returns a - b and the final borrow.
+func sub4(a, b [4]uint64) ([4]uint64, uint64) {
+	var out [4]uint64
+	var borrow uint64
+	for i := 0; i < 4; i++ {
+		out[i], borrow = bits.Sub64(a[i], b[i], borrow)
+	}
+	return out, borrow
+}
+
+// select4 returns a when c is 1 and b when c is 0.
+func select4(c uint64, a, b [4]uint64) [4]uint64 {
+	mask := -c
+	var out [4]uint64
+	for i := 0; i < 4; i++ {
+		out[i] = b[i] ^ (mask & (a[i] ^ b[i]))
+	}
+	return out
+}
+
+// Select returns a when c is 1 and b when c is 0.
+func (f *Field) Select(c uint64, a, b Element) Element {
+	return Element(select4(c, a, b))
+}
+
+func (f *Field) Add(a, b Element) Element {
+	var sum [4]uint64
+	var carry uint64
+	for i := 0; i < 4; i++ {
+		sum[i], carry = bits.Add64(a[i], b[i], carry)
+	}
+	reduced, borrow := sub4(sum, f.m)
+	// Keep the unreduced sum only when it did not overflow and was below m.
+	return Element(select4(borrow&^carry, sum, reduced))
+}
+
+func (f *Field) Sub(a, b Element) Element {
+	diff, borrow := sub4(a, b)
+	var fixed [4]uint64
+	var carry uint64
+	for i := 0; i < 4; i++ {
+		fixed[i], carry = bits.Add64(diff[i], f.m[i]&-borrow, carry)
+	}
+	return Element(fixed)
+}
+
+func (f *Field) Neg(a Element) Element {
+	return f.Sub(Element{}, a)
+}
+
+// Mul returns a*b*R^-1 mod m (CIOS Montgomery multiplication).
+func (f *Field) Mul(a, b Element) Element {
+	var t [6]uint64
+	for i := 0; i < 4; i++ {
+		var c uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(a[j], b[i])
+			var c1, c2 uint64
+			lo, c1 = bits.Add64(lo, t[j], 0)
+			lo, c2 = bits.Add64(lo, c, 0)
+			t[j] = lo
+			c = hi + c1 + c2
+		}
+		t[4], c = bits.Add64(t[4], c, 0)
+		t[5] = c
+
+		u := t[0] * f.mInv
+		hi, lo := bits.Mul64(u, f.m[0])
+		_, carry := bits.Add64(lo, t[0], 0)
+		c = hi + carry
+		for j := 1; j < 4; j++ {
+			hi, lo = bits.Mul64(u, f.m[j])
+			var c1, c2 uint64
+			lo, c1 = bits.Add64(lo, t[j], 0)
+			lo, c2 = bits.Add64(lo, c, 0)
+			t[j-1] = lo
+			c = hi + c1 + c2
+		}
+		t[3], carry = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + carry
+	}
+	res := [4]uint64{t[0], t[1], t[2], t[3]}
+	reduced, borrow := sub4(res, f.m)
+	// t[4] is the bit above the four limbs.
+	return Element(select4(borrow&^t[4], res, reduced))
+}
+
+func (f *Field) Square(a Element) Element {
+	return f.Mul(a, a)
+}
+
+// Exp returns a^e for a public big-endian exponent e.
+func (f *Field) Exp(a Element, e []byte) Element {
+	out := f.one
+	for _, b := range e {
+		for i := 7; i >= 0; i-- {
+			out = f.Square(out)
+			if b>>i&1 == 1 {
+				out = f.Mul(out, a)
+			}
+		}
+	}
+	return out
+}
+
+// Inverse returns a^-1, or zero for zero.
+func (f *Field) Inverse(a Element) Element {
+	e, _ := sub4(f.m, [4]uint64{2})
+	return f.Exp(a, f.toBytes(e))
+}
+
+func (f *Field) Equal(a, b Element) bool {
+	return f.equal(a, b) == 1
+}
+
+// equal returns 1 when a equals b and 0 otherwise.
+func (f *Field) equal(a, b Element) uint64 {
+	var diff uint64
+	for i := 0; i < 4; i++ {
+		diff |= a[i] ^ b[i]
+	}
+	return uint64(subtle.ConstantTimeEq(int32(uint32(diff|diff>>32)), 0))
+}
+
+func (f *Field) IsZero(a Element) bool {
+	return f.equal(a, Element{}) == 1
+}
+
+// IsHigh reports whether a, as an integer in [0, m), exceeds (m-1)/2.
+func (f *Field) IsHigh(a Element) uint64 {
+	v := f.fromMont(a)
+	half := [4]uint64{f.m[0]>>1 | f.m[1]<<63, f.m[1]>>1 | f.m[2]<<63, f.m[2]>>1 | f.m[3]<<63, f.m[3] >> 1}
+	_, borrow := sub4(half, v)
+	return borrow
+}
+
+// IsOdd reports whether a, as an integer in [0, m), is odd.
+func (f *Field) IsOdd(a Element) uint64 {
+	return f.fromMont(a)[0] & 1
+}
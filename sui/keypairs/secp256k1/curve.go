@@ -0,0 +1,66 @@
+package secp256k1
+
+import "github.com/sui-sdks/go-sdks/sui/keypairs/internal/ecc"
+
+// The secp256k1 curve y^2 = x^3 + 7 over the prime field of order p, with a
+// base point G of prime order n (SEC 2, section 2.4.1).
+var (
+	fp = ecc.NewField(ecc.MustHex("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"))
+	fn = ecc.NewField(ecc.MustHex("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"))
+
+	// b3 is 3*b, as used by the complete addition formula.
+	b3 = fp.SetBytes(ecc.MustHex("0000000000000000000000000000000000000000000000000000000000000015"))
+	b  = fp.SetBytes(ecc.MustHex("0000000000000000000000000000000000000000000000000000000000000007"))
+
+	generator = ecc.Point{
+		X: fp.SetBytes(ecc.MustHex("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")),
+		Y: fp.SetBytes(ecc.MustHex("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8")),
+		Z: fp.One(),
+	}
+
+	curve = &ecc.Curve{
+		P: fp, N: fn, A: fp.Zero(), B: b, G: generator,
+		SqrtExp: ecc.MustHex("3fffffffffffffffffffffffffffffffffffffffffffffffffffffffbfffff0c"),
+		Add:     add,
+	}
+)
+
+// add returns p+q using the complete formula for a = 0 of Renes, Costello
+// and Batina (2016, algorithm 7), which also handles doubling and the
+// identity without branches.
+func add(p, q ecc.Point) ecc.Point {
+	t0 := fp.Mul(p.X, q.X)
+	t1 := fp.Mul(p.Y, q.Y)
+	t2 := fp.Mul(p.Z, q.Z)
+	t3 := fp.Add(p.X, p.Y)
+	t4 := fp.Add(q.X, q.Y)
+	t3 = fp.Mul(t3, t4)
+	t4 = fp.Add(t0, t1)
+	t3 = fp.Sub(t3, t4)
+	t4 = fp.Add(p.Y, p.Z)
+	x3 := fp.Add(q.Y, q.Z)
+	t4 = fp.Mul(t4, x3)
+	x3 = fp.Add(t1, t2)
+	t4 = fp.Sub(t4, x3)
+	x3 = fp.Add(p.X, p.Z)
+	y3 := fp.Add(q.X, q.Z)
+	x3 = fp.Mul(x3, y3)
+	y3 = fp.Add(t0, t2)
+	y3 = fp.Sub(x3, y3)
+	x3 = fp.Add(t0, t0)
+	t0 = fp.Add(x3, t0)
+	t2 = fp.Mul(b3, t2)
+	z3 := fp.Add(t1, t2)
+	t1 = fp.Sub(t1, t2)
+	y3 = fp.Mul(b3, y3)
+	x3 = fp.Mul(t4, y3)
+	t2 = fp.Mul(t3, t1)
+	x3 = fp.Sub(t2, x3)
+	y3 = fp.Mul(y3, t0)
+	t1 = fp.Mul(t1, z3)
+	y3 = fp.Add(t1, y3)
+	t0 = fp.Mul(t0, t3)
+	z3 = fp.Mul(z3, t4)
+	z3 = fp.Add(z3, t0)
+	return ecc.Point{X: x3, Y: y3, Z: z3}
+}
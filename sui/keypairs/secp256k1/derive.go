@@ -0,0 +1,75 @@
+package secp256k1
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sui-sdks/go-sdks/sui/cryptography"
+)
+
+// DeriveKeypair derives the keypair at path from a BIP-39 mnemonic, as Sui
+// wallets do. An empty path means DefaultDerivationPath.
+func DeriveKeypair(mnemonic, path string) (*Keypair, error) {
+	seed, err := cryptography.MnemonicToSeed(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	return DeriveKeypairFromSeed(seed, path)
+}
+
+// DeriveKeypairFromSeed derives the keypair at path from a BIP-39 seed. An
+// empty path means DefaultDerivationPath.
+func DeriveKeypairFromSeed(seed []byte, path string) (*Keypair, error) {
+	if path == "" {
+		path = DefaultDerivationPath
+	}
+	if !cryptography.IsValidBIP32Path(path) || !strings.HasPrefix(path, "m/54'/") {
+		return nil, fmt.Errorf("invalid derivation path: %s", path)
+	}
+	key, err := DeriveSecretKey(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return FromSecretKey(key)
+}
+
+// DeriveSecretKey derives the private key at path from seed with BIP-32.
+func DeriveSecretKey(seed []byte, path string) ([]byte, error) {
+	indexes, err := cryptography.ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	il, chainCode := hmacSHA512([]byte("Bitcoin seed"), seed)
+	key, ok := fn.SetCanonicalBytes(il)
+	if !ok || fn.IsZero(key) {
+		return nil, errors.New("seed does not produce a valid master key")
+	}
+	for _, index := range indexes {
+		data := make([]byte, 0, 37)
+		if index >= cryptography.HardenedOffset {
+			data = append(append(data, 0), fn.Bytes(key)...)
+		} else {
+			data = append(data, curve.Compress(curve.ScalarBaseMult(fn.Bytes(key)))...)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+		il, chainCode = hmacSHA512(chainCode, data)
+		tweak, ok := fn.SetCanonicalBytes(il)
+		key = fn.Add(key, tweak)
+		// BIP-32 skips such indexes, which happens with probability 2^-127.
+		if !ok || fn.IsZero(key) {
+			return nil, fmt.Errorf("path %s derives an invalid key", path)
+		}
+	}
+	return fn.Bytes(key), nil
+}
+
+func hmacSHA512(key, data []byte) ([]byte, []byte) {
+	m := hmac.New(sha512.New, key)
+	m.Write(data)
+	sum := m.Sum(nil)
+	return sum[:32], sum[32:]
+}
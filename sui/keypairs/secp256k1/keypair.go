@@ -1,11 +1,9 @@
 package secp256k1
 
 import (
-	"crypto/ecdsa"
-	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	"fmt"
-	"math/big"
 
 	"github.com/sui-sdks/go-sdks/sui/cryptography"
 )
@@ -13,39 +11,37 @@ import (
 const DefaultDerivationPath = "m/54'/784'/0'/0/0"
 
 type Keypair struct {
-	privateKey *ecdsa.PrivateKey
-	publicKey  *PublicKey
+	secretKey []byte
+	publicKey *PublicKey
 }
 
 func Generate() (*Keypair, error) {
-	curve := elliptic.P256()
-	prv, err := ecdsa.GenerateKey(curve, rand.Reader)
-	if err != nil {
-		return nil, err
+	for {
+		secret := make([]byte, cryptography.PrivateKeySize)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		if kp, err := FromSecretKey(secret); err == nil {
+			return kp, nil
+		}
 	}
-	comp := elliptic.MarshalCompressed(curve, prv.PublicKey.X, prv.PublicKey.Y)
-	pk, _ := NewPublicKey(comp)
-	return &Keypair{privateKey: prv, publicKey: pk}, nil
 }
 
-func FromSeed(seed []byte) (*Keypair, error) {
-	if len(seed) != cryptography.PrivateKeySize {
-		return nil, fmt.Errorf("wrong secret key size: %d", len(seed))
+func FromSeed(seed []byte) (*Keypair, error) { return FromSecretKey(seed) }
+
+// FromSecretKey returns the keypair for a 32-byte private scalar in [1, n).
+func FromSecretKey(secretKey []byte) (*Keypair, error) {
+	if len(secretKey) != cryptography.PrivateKeySize {
+		return nil, fmt.Errorf("wrong secret key size: %d", len(secretKey))
 	}
-	curve := elliptic.P256()
-	d := new(big.Int).SetBytes(seed)
-	n := new(big.Int).Sub(curve.Params().N, big.NewInt(1))
-	d.Mod(d, n)
-	d.Add(d, big.NewInt(1))
-	x, y := curve.ScalarBaseMult(d.Bytes())
-	prv := &ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: curve, X: x, Y: y}, D: d}
-	comp := elliptic.MarshalCompressed(curve, x, y)
-	pk, _ := NewPublicKey(comp)
-	return &Keypair{privateKey: prv, publicKey: pk}, nil
+	if _, err := curve.PrivateScalar(secretKey); err != nil {
+		return nil, err
+	}
+	secret := append([]byte(nil), secretKey...)
+	pk, _ := NewPublicKey(curve.Compress(curve.ScalarBaseMult(secret)))
+	return &Keypair{secretKey: secret, publicKey: pk}, nil
 }
 
-func FromSecretKey(secretKey []byte) (*Keypair, error) { return FromSeed(secretKey) }
-
 func FromSecretKeyString(secretKey string) (*Keypair, error) {
 	decoded, err := cryptography.DecodeSuiPrivateKey(secretKey)
 	if err != nil {
@@ -57,10 +53,15 @@ func FromSecretKeyString(secretKey string) (*Keypair, error) {
 	return FromSecretKey(decoded.SecretKey)
 }
 
-func (k *Keypair) Sign(bytes []byte) ([]byte, error) { return ecdsa.SignASN1(rand.Reader, k.privateKey, bytes) }
+// Sign signs the SHA-256 hash of bytes, returning a 64-byte r||s signature
+// with a deterministic RFC 6979 nonce and low s.
+func (k *Keypair) Sign(bytes []byte) ([]byte, error) {
+	hash := sha256.Sum256(bytes)
+	return curve.Sign(k.secretKey, hash[:])
+}
 func (k *Keypair) GetKeyScheme() cryptography.SignatureScheme { return cryptography.SchemeSecp256k1 }
-func (k *Keypair) GetPublicKey() cryptography.PublicKey { return k.publicKey }
-func (k *Keypair) ToSuiAddress() string { return k.publicKey.ToSuiAddress() }
+func (k *Keypair) GetPublicKey() cryptography.PublicKey       { return k.publicKey }
+func (k *Keypair) ToSuiAddress() string                       { return k.publicKey.ToSuiAddress() }
 func (k *Keypair) SignWithIntent(bytes []byte, intent cryptography.IntentScope) (cryptography.SignatureWithBytes, error) {
 	return cryptography.SignWithIntent(k, bytes, intent)
 }
@@ -71,9 +72,6 @@ func (k *Keypair) SignPersonalMessage(bytes []byte) (cryptography.SignatureWithB
 	return cryptography.SignPersonalMessage(k, bytes)
 }
 func (k *Keypair) GetSecretKey() string {
-	b := k.privateKey.D.Bytes()
-	seed := make([]byte, cryptography.PrivateKeySize)
-	copy(seed[cryptography.PrivateKeySize-len(b):], b)
-	v, _ := cryptography.EncodeSuiPrivateKey(seed, k.GetKeyScheme())
+	v, _ := cryptography.EncodeSuiPrivateKey(k.secretKey, k.GetKeyScheme())
 	return v
 }
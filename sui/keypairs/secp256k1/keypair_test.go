@@ -1,6 +1,10 @@
 package secp256k1
 
-import "testing"
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
 
 func TestSecp256k1SignVerify(t *testing.T) {
 	kp, err := Generate()
@@ -15,4 +19,124 @@ func TestSecp256k1SignVerify(t *testing.T) {
 	if !kp.GetPublicKey().Verify(digest, sig) {
 		t.Fatalf("verify failed")
 	}
+	restored, err := FromSecretKeyString(kp.GetSecretKey())
+	if err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if !bytes.Equal(restored.GetPublicKey().ToRawBytes(), kp.GetPublicKey().ToRawBytes()) {
+		t.Fatalf("restored public key mismatch")
+	}
+}
+
+func scalar(b byte) []byte {
+	out := make([]byte, 32)
+	out[31] = b
+	return out
+}
+
+func TestSecp256k1Curve(t *testing.T) {
+	if got := hex.EncodeToString(curve.Compress(curve.ScalarBaseMult(scalar(2)))); got != "02c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5" {
+		t.Fatalf("2G mismatch: %s", got)
+	}
+	if !curve.IsIdentity(curve.ScalarBaseMult(fn.Modulus())) {
+		t.Fatalf("nG must be the identity")
+	}
+	g, err := curve.Decompress(curve.Compress(generator))
+	if err != nil || !bytes.Equal(curve.Compress(g), curve.Compress(generator)) {
+		t.Fatalf("generator did not round trip: %v", err)
+	}
+	notOnCurve := append([]byte{0x02}, scalar(5)...)
+	if _, err := NewPublicKey(notOnCurve); err == nil {
+		t.Fatalf("expected a point off the curve to be rejected")
+	}
+}
+
+// Vectors from the TS SDK and from bitcoinjs/noble RFC 6979 fixtures, which
+// sign the SHA-256 hash of the message.
+func TestSecp256k1Vectors(t *testing.T) {
+	secret := []byte{59, 148, 11, 85, 134, 130, 61, 253, 2, 174, 59, 70, 27, 180, 51, 107, 94, 203, 174, 253, 102, 39, 170, 146, 46, 252, 4, 143, 236, 12, 136, 28}
+	kp, err := FromSecretKey(secret)
+	if err != nil {
+		t.Fatalf("from secret key failed: %v", err)
+	}
+	if got := hex.EncodeToString(kp.GetPublicKey().ToRawBytes()); got != "021d152307c6b72b0ed0418b0e70cd80e7f5295b8d86f5722d3f5213fbd2394f36" {
+		t.Fatalf("public key mismatch: %s", got)
+	}
+
+	one, _ := FromSecretKey(scalar(1))
+	cases := []struct{ message, sig string }{
+		{"Satoshi Nakamoto", "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d82442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5"},
+		{"All those moments will be lost in time, like tears in rain. Time to die...", "8600dbd41e348fe5c9465ab92d23e3db8b98b873beecd930736488696438cb6b547fe64427496db33bf66019dacbf0039c04199abb0122918601db38a72cfc21"},
+	}
+	for _, c := range cases {
+		sig, err := one.Sign([]byte(c.message))
+		if err != nil {
+			t.Fatalf("sign failed: %v", err)
+		}
+		if got := hex.EncodeToString(sig); got != c.sig {
+			t.Fatalf("signature of %q mismatch: %s", c.message, got)
+		}
+		if !one.GetPublicKey().Verify([]byte(c.message), sig) {
+			t.Fatalf("verify of %q failed", c.message)
+		}
+	}
+}
+
+func TestSecp256k1RejectsNonCanonicalSignatures(t *testing.T) {
+	kp, _ := FromSecretKey(scalar(1))
+	msg := []byte("Satoshi Nakamoto")
+	sig, _ := kp.Sign(msg)
+
+	// n - s verifies under plain ECDSA but is not low-s.
+	high := append([]byte(nil), sig...)
+	copy(high[32:], fn.Bytes(fn.Neg(fn.SetBytes(sig[32:]))))
+	if kp.GetPublicKey().Verify(msg, high) {
+		t.Fatalf("expected a high-s signature to be rejected")
+	}
+	if kp.GetPublicKey().Verify(msg, sig[:63]) {
+		t.Fatalf("expected a short signature to be rejected")
+	}
+	if _, err := FromSecretKey(make([]byte, 32)); err == nil {
+		t.Fatalf("expected a zero secret key to be rejected")
+	}
+	if _, err := FromSecretKey(fn.Modulus()); err == nil {
+		t.Fatalf("expected a secret key of n to be rejected")
+	}
+}
+
+// BIP-32 test vector 1.
+func TestBIP32Vectors(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	cases := map[string]string{
+		"m/0'":      "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+		"m/0'/1":    "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+		"m/0'/1/2'": "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca",
+	}
+	for path, want := range cases {
+		key, err := DeriveSecretKey(seed, path)
+		if err != nil {
+			t.Fatalf("derive %s failed: %v", path, err)
+		}
+		if got := hex.EncodeToString(key); got != want {
+			t.Fatalf("key at %s mismatch: %s", path, got)
+		}
+	}
+}
+
+// The vector is shared with the TS SDK.
+func TestSecp256k1DeriveKeypair(t *testing.T) {
+	mnemonic := "film crazy soon outside stand loop subway crumble thrive popular green nuclear struggle pistol arm wife phrase warfare march wheat nephew ask sunny firm"
+	kp, err := DeriveKeypair(mnemonic, "")
+	if err != nil {
+		t.Fatalf("derive failed: %v", err)
+	}
+	if got := kp.ToSuiAddress(); got != "0x9e8f732575cc5386f8df3c784cd3ed1b53ce538da79926b2ad54dcc1197d2532" {
+		t.Fatalf("address mismatch: %s", got)
+	}
+	if _, err := DeriveKeypair(mnemonic, "m/74'/784'/0'/0/0"); err == nil {
+		t.Fatalf("expected a secp256r1 path to be rejected")
+	}
+	if _, err := DeriveKeypair("film crazy soon", ""); err == nil {
+		t.Fatalf("expected an invalid mnemonic to be rejected")
+	}
 }
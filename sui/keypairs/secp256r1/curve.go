@@ -0,0 +1,75 @@
+package secp256r1
+
+import "github.com/sui-sdks/go-sdks/sui/keypairs/internal/ecc"
+
+// The NIST P-256 curve y^2 = x^3 - 3x + b over the prime field of order p,
+// with a base point G of prime order n (SEC 2, section 2.4.2).
+var (
+	fp = ecc.NewField(ecc.MustHex("ffffffff00000001000000000000000000000000ffffffffffffffffffffffff"))
+	fn = ecc.NewField(ecc.MustHex("ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551"))
+
+	a = fp.SetBytes(ecc.MustHex("ffffffff00000001000000000000000000000000fffffffffffffffffffffffc"))
+	b = fp.SetBytes(ecc.MustHex("5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d2604b"))
+
+	generator = ecc.Point{
+		X: fp.SetBytes(ecc.MustHex("6b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296")),
+		Y: fp.SetBytes(ecc.MustHex("4fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5")),
+		Z: fp.One(),
+	}
+
+	curve = &ecc.Curve{
+		P: fp, N: fn, A: a, B: b, G: generator,
+		SqrtExp: ecc.MustHex("3fffffffc0000000400000000000000000000000400000000000000000000000"),
+		Add:     add,
+	}
+)
+
+// add returns p+q using the complete formula for a = -3 of Renes, Costello
+// and Batina (2016, algorithm 4), which also handles doubling and the
+// identity without branches.
+func add(p, q ecc.Point) ecc.Point {
+	t0 := fp.Mul(p.X, q.X)
+	t1 := fp.Mul(p.Y, q.Y)
+	t2 := fp.Mul(p.Z, q.Z)
+	t3 := fp.Add(p.X, p.Y)
+	t4 := fp.Add(q.X, q.Y)
+	t3 = fp.Mul(t3, t4)
+	t4 = fp.Add(t0, t1)
+	t3 = fp.Sub(t3, t4)
+	t4 = fp.Add(p.Y, p.Z)
+	x3 := fp.Add(q.Y, q.Z)
+	t4 = fp.Mul(t4, x3)
+	x3 = fp.Add(t1, t2)
+	t4 = fp.Sub(t4, x3)
+	x3 = fp.Add(p.X, p.Z)
+	y3 := fp.Add(q.X, q.Z)
+	x3 = fp.Mul(x3, y3)
+	y3 = fp.Add(t0, t2)
+	y3 = fp.Sub(x3, y3)
+	z3 := fp.Mul(b, t2)
+	x3 = fp.Sub(y3, z3)
+	z3 = fp.Add(x3, x3)
+	x3 = fp.Add(x3, z3)
+	z3 = fp.Sub(t1, x3)
+	x3 = fp.Add(t1, x3)
+	y3 = fp.Mul(b, y3)
+	t1 = fp.Add(t2, t2)
+	t2 = fp.Add(t1, t2)
+	y3 = fp.Sub(y3, t2)
+	y3 = fp.Sub(y3, t0)
+	t1 = fp.Add(y3, y3)
+	y3 = fp.Add(t1, y3)
+	t1 = fp.Add(t0, t0)
+	t0 = fp.Add(t1, t0)
+	t0 = fp.Sub(t0, t2)
+	t1 = fp.Mul(t4, y3)
+	t2 = fp.Mul(t0, y3)
+	y3 = fp.Mul(x3, z3)
+	y3 = fp.Add(y3, t2)
+	x3 = fp.Mul(t3, x3)
+	x3 = fp.Sub(x3, t1)
+	z3 = fp.Mul(t4, z3)
+	t1 = fp.Mul(t3, t0)
+	z3 = fp.Add(z3, t1)
+	return ecc.Point{X: x3, Y: y3, Z: z3}
+}
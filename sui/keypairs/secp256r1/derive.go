@@ -0,0 +1,38 @@
+package secp256r1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sui-sdks/go-sdks/sui/cryptography"
+	"github.com/sui-sdks/go-sdks/sui/keypairs/secp256k1"
+)
+
+// DeriveKeypair derives the keypair at path from a BIP-39 mnemonic, as Sui
+// wallets do. An empty path means DefaultDerivationPath.
+func DeriveKeypair(mnemonic, path string) (*Keypair, error) {
+	seed, err := cryptography.MnemonicToSeed(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	return DeriveKeypairFromSeed(seed, path)
+}
+
+// DeriveKeypairFromSeed derives the keypair at path from a BIP-39 seed. An
+// empty path means DefaultDerivationPath.
+//
+// Like the Sui CLI and TS SDK, this walks the secp256k1 BIP-32 tree and uses
+// the resulting private key as a P-256 scalar.
+func DeriveKeypairFromSeed(seed []byte, path string) (*Keypair, error) {
+	if path == "" {
+		path = DefaultDerivationPath
+	}
+	if !cryptography.IsValidBIP32Path(path) || !strings.HasPrefix(path, "m/74'/") {
+		return nil, fmt.Errorf("invalid derivation path: %s", path)
+	}
+	key, err := secp256k1.DeriveSecretKey(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return FromSecretKey(key)
+}
@@ -1,6 +1,16 @@
 package secp256r1
 
-import "testing"
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
 
 func TestSecp256r1SignVerify(t *testing.T) {
 	kp, err := Generate()
@@ -15,4 +25,167 @@ func TestSecp256r1SignVerify(t *testing.T) {
 	if !kp.GetPublicKey().Verify(digest, sig) {
 		t.Fatalf("verify failed")
 	}
+	restored, err := FromSecretKeyString(kp.GetSecretKey())
+	if err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if !bytes.Equal(restored.GetPublicKey().ToRawBytes(), kp.GetPublicKey().ToRawBytes()) {
+		t.Fatalf("restored public key mismatch")
+	}
+}
+
+// The curve arithmetic is checked against the standard library.
+func TestSecp256r1MatchesStdlib(t *testing.T) {
+	for i := 0; i < 8; i++ {
+		kp, err := Generate()
+		if err != nil {
+			t.Fatalf("generate failed: %v", err)
+		}
+		priv, err := ecdh.P256().NewPrivateKey(kp.secretKey)
+		if err != nil {
+			t.Fatalf("stdlib rejected key: %v", err)
+		}
+		x, y := elliptic.Unmarshal(elliptic.P256(), priv.PublicKey().Bytes())
+		if want := elliptic.MarshalCompressed(elliptic.P256(), x, y); !bytes.Equal(kp.GetPublicKey().ToRawBytes(), want) {
+			t.Fatalf("public key mismatch: %x != %x", kp.GetPublicKey().ToRawBytes(), want)
+		}
+
+		msg := []byte{byte(i), 1, 2, 3}
+		sig, _ := kp.Sign(msg)
+		hash := sha256Sum(msg)
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		if !ecdsa.Verify(pub, hash, new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])) {
+			t.Fatalf("stdlib rejected signature %x", sig)
+		}
+	}
+	if !curve.IsIdentity(curve.ScalarBaseMult(fn.Modulus())) {
+		t.Fatalf("nG must be the identity")
+	}
+}
+
+// RFC 6979, appendix A.2.5: P-256 with SHA-256. The RFC's s values are
+// normalized to low s.
+func TestSecp256r1RFC6979Vectors(t *testing.T) {
+	secret, _ := hex.DecodeString("c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721")
+	kp, err := FromSecretKey(secret)
+	if err != nil {
+		t.Fatalf("from secret key failed: %v", err)
+	}
+	if got := hex.EncodeToString(kp.GetPublicKey().ToRawBytes()); got != "0360fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6" {
+		t.Fatalf("public key mismatch: %s", got)
+	}
+	cases := []struct{ message, r, s string }{
+		{"sample", "efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716", "f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8"},
+		{"test", "f1abb023518351cd71d881567b1ea663ed3efcf6c5132b354f28d3b0b7d38367", "019f4113742a2b14bd25926b49c649155f267e60d3814b4c0cc84250e46f0083"},
+	}
+	for _, c := range cases {
+		s, _ := hex.DecodeString(c.s)
+		low := fn.SetBytes(s)
+		if fn.IsHigh(low) == 1 {
+			low = fn.Neg(low)
+		}
+		want := c.r + hex.EncodeToString(fn.Bytes(low))
+		sig, err := kp.Sign([]byte(c.message))
+		if err != nil {
+			t.Fatalf("sign failed: %v", err)
+		}
+		if got := hex.EncodeToString(sig); got != want {
+			t.Fatalf("signature of %q mismatch: %s", c.message, got)
+		}
+		if !kp.GetPublicKey().Verify([]byte(c.message), sig) {
+			t.Fatalf("verify of %q failed", c.message)
+		}
+	}
+}
+
+// The secret and public key are the TS SDK's test vectors. The signature of
+// "hello" was computed independently, with RFC 6979 and P-256 arithmetic in
+// Python.
+func TestSecp256r1TSVectors(t *testing.T) {
+	secret := []byte{66, 37, 141, 205, 161, 76, 241, 17, 198, 2, 184, 151, 27, 140, 200, 67, 233, 30, 70, 202, 144, 81, 81, 192, 39, 68, 166, 176, 23, 230, 147, 22}
+	public := []byte{2, 39, 50, 43, 58, 137, 26, 10, 40, 13, 107, 193, 251, 44, 187, 35, 210, 143, 84, 144, 111, 214, 64, 127, 95, 116, 31, 109, 239, 87, 98, 96, 154}
+	kp, err := FromSecretKey(secret)
+	if err != nil {
+		t.Fatalf("from secret key failed: %v", err)
+	}
+	if !bytes.Equal(kp.GetPublicKey().ToRawBytes(), public) {
+		t.Fatalf("public key mismatch: %v", kp.GetPublicKey().ToRawBytes())
+	}
+	sig, err := kp.Sign([]byte("hello"))
+	if err != nil {
+		t.Fatalf("sign failed: %v", err)
+	}
+	if got := hex.EncodeToString(sig); got != "27629f3c4aa3ae5b7c7edebe73c6ce55e9faa25c1e4fae2227834347f9f41b7225437f027841b4ecfffe29abce066d2c127a6d5fe7fb8911729e88dac818d6c6" {
+		t.Fatalf("signature mismatch: %s", got)
+	}
+}
+
+func TestSecp256r1RejectsInvalidInput(t *testing.T) {
+	kp, _ := Generate()
+	msg := []byte("sample")
+	sig, _ := kp.Sign(msg)
+
+	high := append([]byte(nil), sig...)
+	copy(high[32:], fn.Bytes(fn.Neg(fn.SetBytes(sig[32:]))))
+	if kp.GetPublicKey().Verify(msg, high) {
+		t.Fatalf("expected a high-s signature to be rejected")
+	}
+	if kp.GetPublicKey().Verify(msg, sig[:63]) {
+		t.Fatalf("expected a short signature to be rejected")
+	}
+	// x = 1 gives y^2 = b - 2, which is not a square.
+	offCurve := make([]byte, 33)
+	offCurve[0], offCurve[32] = 0x02, 1
+	if _, err := NewPublicKey(offCurve); err == nil {
+		t.Fatalf("expected a point off the curve to be rejected")
+	}
+	if _, err := NewPublicKey(append([]byte{0x04}, kp.GetPublicKey().ToRawBytes()[1:]...)); err == nil {
+		t.Fatalf("expected an invalid prefix to be rejected")
+	}
+	if _, err := FromSecretKey(fn.Modulus()); err == nil {
+		t.Fatalf("expected a secret key of n to be rejected")
+	}
+}
+
+func sha256Sum(b []byte) []byte {
+	h := sha256.Sum256(b)
+	return h[:]
+}
+
+func TestSecp256r1DeriveKeypair(t *testing.T) {
+	mnemonic := "film crazy soon outside stand loop subway crumble thrive popular green nuclear struggle pistol arm wife phrase warfare march wheat nephew ask sunny firm"
+	kp, err := DeriveKeypair(mnemonic, "")
+	if err != nil {
+		t.Fatalf("derive failed: %v", err)
+	}
+	other, err := DeriveKeypair(mnemonic, "m/74'/784'/0'/0/1")
+	if err != nil {
+		t.Fatalf("derive failed: %v", err)
+	}
+	if kp.ToSuiAddress() == other.ToSuiAddress() {
+		t.Fatalf("expected different paths to derive different keys")
+	}
+	if _, err := DeriveKeypair(mnemonic, "m/54'/784'/0'/0/0"); err == nil {
+		t.Fatalf("expected a secp256k1 path to be rejected")
+	}
+
+	// Mnemonics and addresses from the TS SDK's secp256r1 tests, derived at
+	// the default path.
+	cases := []struct{ mnemonic, publicKey, address string }{
+		{"act wing dilemma glory episode region allow mad tourist humble muffin oblige", "AsvVK1RFYYB6af/ysWfcWxENV879Kx/5tsVq6AYG13vT", "0x4a822457f1970468d38dae8e63fb60eefdaa497d74d781f581ea2d137ec36f3a"},
+		{"flag rebel cabbage captain minimum purpose long already valley horn enrich salt", "AzZpkqmZOsqzwe7K85lDZMJDSzuqXkCB/iCJNy7oW08c", "0xcd43ecb9dd32249ff5748f5e4d51855b01c9b1b8bbe7f8638bb8ab4cb463b920"},
+		{"area renew bar language pudding trial small host remind supreme cabbage era", "AnQGuzEYraAjemXubovrshe6wqj33Di0wMNkLBeGdSb9", "0x0d9047b7e7b698cc09c955ea97b0c68c2be7fb3aebeb59edcc84b1fb87e0f28e"},
+	}
+	for _, c := range cases {
+		kp, err := DeriveKeypair(c.mnemonic, "")
+		if err != nil {
+			t.Fatalf("derive failed: %v", err)
+		}
+		if got := base64.StdEncoding.EncodeToString(kp.GetPublicKey().ToRawBytes()); got != c.publicKey {
+			t.Fatalf("public key of %q mismatch: %s", c.mnemonic, got)
+		}
+		if got := kp.ToSuiAddress(); got != c.address {
+			t.Fatalf("address of %q mismatch: %s", c.mnemonic, got)
+		}
+	}
 }
@@ -1,8 +1,7 @@
 package secp256r1
 
 import (
-	"crypto/ecdsa"
-	"crypto/elliptic"
+	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
 
@@ -13,10 +12,15 @@ const PublicKeySize = 33
 
 type PublicKey struct{ data []byte }
 
+// NewPublicKey parses a 33-byte compressed public key, rejecting points that
+// are not on the curve.
 func NewPublicKey(value []byte) (*PublicKey, error) {
 	if len(value) != PublicKeySize {
 		return nil, fmt.Errorf("invalid public key size: %d", len(value))
 	}
+	if _, err := curve.Decompress(value); err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
 	return &PublicKey{data: append([]byte(nil), value...)}, nil
 }
 func NewPublicKeyFromBase64(value string) (*PublicKey, error) {
@@ -28,13 +32,14 @@ func NewPublicKeyFromBase64(value string) (*PublicKey, error) {
 }
 
 func (p *PublicKey) ToRawBytes() []byte { return append([]byte(nil), p.data...) }
-func (p *PublicKey) Flag() byte { return cryptography.SignatureSchemeToFlag[cryptography.SchemeSecp256r1] }
+func (p *PublicKey) Flag() byte {
+	return cryptography.SignatureSchemeToFlag[cryptography.SchemeSecp256r1]
+}
+
+// Verify reports whether signature is a 64-byte low-s r||s signature of the
+// SHA-256 hash of data.
 func (p *PublicKey) Verify(data, signature []byte) bool {
-	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), p.data)
-	if x == nil {
-		return false
-	}
-	pub := ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
-	return ecdsa.VerifyASN1(&pub, data, signature)
+	hash := sha256.Sum256(data)
+	return curve.Verify(p.data, hash[:], signature)
 }
 func (p *PublicKey) ToSuiAddress() string { return cryptography.ToSuiAddress(p) }
@@ -0,0 +1,350 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sui-sdks/go-sdks/sui/jsonrpc"
+)
+
+const (
+	// ClientConfigFile and KeystoreFile are the file names the Sui CLI uses in
+	// its config directory.
+	ClientConfigFile = "client.yaml"
+	KeystoreFile     = "sui.keystore"
+)
+
+// DefaultConfigDir returns the Sui CLI config directory: $SUI_CONFIG_DIR when
+// set, otherwise ~/.sui/sui_config.
+func DefaultConfigDir() (string, error) {
+	if dir := os.Getenv("SUI_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".sui", "sui_config"), nil
+}
+
+// Env is a network environment of client.yaml.
+type Env struct {
+	Alias string
+	RPC   string
+	WS    string
+	// extra holds the lines of fields not modeled here, such as basic_auth,
+	// so that saving does not drop them.
+	extra []string
+}
+
+// ClientConfig is the Sui CLI client.yaml. Top-level fields not modeled here
+// are kept as they are when the config is saved.
+type ClientConfig struct {
+	// Keystore is the path of the sui.keystore file.
+	Keystore      string
+	Envs          []Env
+	ActiveEnv     string
+	ActiveAddress string
+	extra         []string
+}
+
+// LoadClientConfig reads a client.yaml file.
+func LoadClientConfig(path string) (*ClientConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseClientConfig(data)
+}
+
+// Save writes the config to path, readable only by the owner.
+func (c *ClientConfig) Save(path string) error {
+	return writeFile(path, c.Marshal())
+}
+
+// LoadKeystore reads the keystore the config points to.
+func (c *ClientConfig) LoadKeystore() (*Keystore, error) {
+	if c.Keystore == "" {
+		return nil, errors.New("client config has no keystore file")
+	}
+	return LoadFile(c.Keystore)
+}
+
+// Env returns the environment with alias.
+func (c *ClientConfig) Env(alias string) (Env, bool) {
+	for _, e := range c.Envs {
+		if e.Alias == alias {
+			return e, true
+		}
+	}
+	return Env{}, false
+}
+
+// ActiveEnvironment returns the active environment.
+func (c *ClientConfig) ActiveEnvironment() (Env, bool) {
+	return c.Env(c.ActiveEnv)
+}
+
+// AddEnv adds an environment with a new alias.
+func (c *ClientConfig) AddEnv(env Env) error {
+	if env.Alias == "" || env.RPC == "" {
+		return errors.New("environment needs an alias and an rpc url")
+	}
+	if _, ok := c.Env(env.Alias); ok {
+		return fmt.Errorf("environment %q already exists", env.Alias)
+	}
+	c.Envs = append(c.Envs, env)
+	return nil
+}
+
+// SetActiveEnv switches to the environment with alias.
+func (c *ClientConfig) SetActiveEnv(alias string) error {
+	if _, ok := c.Env(alias); !ok {
+		return fmt.Errorf("environment %q not found", alias)
+	}
+	c.ActiveEnv = alias
+	return nil
+}
+
+// SetActiveAddress switches to the key of ks with an address or alias.
+func (c *ClientConfig) SetActiveAddress(ks *Keystore, addressOrAlias string) error {
+	entry, err := ks.Entry(addressOrAlias)
+	if err != nil {
+		return err
+	}
+	c.ActiveAddress = entry.Address
+	return nil
+}
+
+// ParseClientConfig parses the subset of YAML the Sui CLI writes for
+// client.yaml: top-level mappings, the keystore File mapping and the envs
+// sequence, with plain, single- or double-quoted scalars.
+func ParseClientConfig(data []byte) (*ClientConfig, error) {
+	c := &ClientConfig{}
+	for _, block := range splitBlocks(yamlLines(string(data)), 0) {
+		key, value, ok := splitKey(block[0])
+		if !ok {
+			return nil, fmt.Errorf("invalid client config line: %q", block[0])
+		}
+		children := block[1:]
+		switch key {
+		case "keystore":
+			path, ok := parseKeystore(value, children)
+			if !ok {
+				// Only file keystores are supported; keep others untouched.
+				c.extra = append(c.extra, block...)
+				continue
+			}
+			c.Keystore = path
+		case "envs":
+			envs, err := parseEnvs(value, children)
+			if err != nil {
+				return nil, err
+			}
+			c.Envs = envs
+		case "active_env":
+			c.ActiveEnv = parseScalar(value)
+		case "active_address":
+			c.ActiveAddress = parseScalar(value)
+		default:
+			c.extra = append(c.extra, block...)
+		}
+	}
+	return c, nil
+}
+
+// yamlLines drops document markers, comments and blank lines.
+func yamlLines(data string) []string {
+	var out []string
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "---" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// splitBlocks groups lines into blocks that each start with a line at indent.
+// A sequence may sit at the same indent as its parent key, so "- " lines at
+// indent stay in the current block.
+func splitBlocks(lines []string, indent int) [][]string {
+	var blocks [][]string
+	for _, line := range lines {
+		i := indentOf(line)
+		starts := i <= indent && !strings.HasPrefix(line[i:], "- ")
+		if starts || len(blocks) == 0 {
+			blocks = append(blocks, []string{line})
+			continue
+		}
+		blocks[len(blocks)-1] = append(blocks[len(blocks)-1], line)
+	}
+	return blocks
+}
+
+func splitKey(line string) (key, value string, ok bool) {
+	key, value, ok = strings.Cut(strings.TrimSpace(line), ":")
+	if !ok || (value != "" && value[0] != ' ') {
+		return "", "", false
+	}
+	return strings.TrimSpace(key), strings.TrimSpace(value), true
+}
+
+func parseKeystore(value string, children []string) (string, bool) {
+	if value != "" || len(children) != 1 {
+		return "", false
+	}
+	key, path, ok := splitKey(children[0])
+	if !ok || key != "File" {
+		return "", false
+	}
+	return parseScalar(path), true
+}
+
+func parseEnvs(value string, children []string) ([]Env, error) {
+	if value == "[]" || (value == "" && len(children) == 0) {
+		return nil, nil
+	}
+	if value != "" {
+		return nil, fmt.Errorf("invalid envs: %q", value)
+	}
+	var envs []Env
+	for _, item := range splitItems(children) {
+		env := Env{}
+		for _, field := range splitBlocks(item, indentOf(item[0])) {
+			key, value, ok := splitKey(field[0])
+			if !ok {
+				return nil, fmt.Errorf("invalid env line: %q", field[0])
+			}
+			switch {
+			case key == "alias" && len(field) == 1:
+				env.Alias = parseScalar(value)
+			case key == "rpc" && len(field) == 1:
+				env.RPC = parseScalar(value)
+			case key == "ws" && len(field) == 1:
+				env.WS = parseScalar(value)
+			default:
+				base := indentOf(field[0])
+				for _, line := range field {
+					env.extra = append(env.extra, line[min(base, indentOf(line)):])
+				}
+			}
+		}
+		envs = append(envs, env)
+	}
+	return envs, nil
+}
+
+// splitItems splits the lines of a sequence of mappings into one mapping per
+// item, replacing the "- " marker with spaces so that the item's keys line up.
+func splitItems(lines []string) [][]string {
+	var items [][]string
+	itemIndent := -1
+	for _, line := range lines {
+		i := indentOf(line)
+		if strings.HasPrefix(line[i:], "- ") && (itemIndent < 0 || i == itemIndent) {
+			itemIndent = i
+			items = append(items, []string{strings.Repeat(" ", i+2) + line[i+2:]})
+			continue
+		}
+		if len(items) > 0 {
+			items[len(items)-1] = append(items[len(items)-1], line)
+		}
+	}
+	return items
+}
+
+func parseScalar(value string) string {
+	switch {
+	case value == "~" || value == "null":
+		return ""
+	case strings.HasPrefix(value, `"`):
+		if s, err := strconv.Unquote(value); err == nil {
+			return s
+		}
+		return strings.Trim(value, `"`)
+	case strings.HasPrefix(value, "'"):
+		return strings.ReplaceAll(strings.Trim(value, "'"), "''", "'")
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value
+}
+
+var plainScalar = regexp.MustCompile(`^[A-Za-z/_.][A-Za-z0-9_./-]*$`)
+
+// formatScalar writes s plain when YAML reads it back as the same string and
+// double-quoted otherwise, as the Sui CLI does for URLs and addresses.
+func formatScalar(s string) string {
+	if s == "" {
+		return "~"
+	}
+	switch strings.ToLower(s) {
+	case "null", "true", "false", "yes", "no", "on", "off", ".nan", ".inf":
+		return strconv.Quote(s)
+	}
+	if plainScalar.MatchString(s) {
+		return s
+	}
+	return strconv.Quote(s)
+}
+
+// Marshal returns the config as client.yaml.
+func (c *ClientConfig) Marshal() []byte {
+	var b strings.Builder
+	b.WriteString("---\n")
+	if c.Keystore != "" {
+		fmt.Fprintf(&b, "keystore:\n  File: %s\n", formatScalar(c.Keystore))
+	}
+	for _, line := range c.extra {
+		b.WriteString(line + "\n")
+	}
+	if len(c.Envs) == 0 {
+		b.WriteString("envs: []\n")
+	} else {
+		b.WriteString("envs:\n")
+	}
+	for _, env := range c.Envs {
+		fmt.Fprintf(&b, "  - alias: %s\n    rpc: %s\n    ws: %s\n", formatScalar(env.Alias), formatScalar(env.RPC), formatScalar(env.WS))
+		for _, line := range env.extra {
+			b.WriteString("    " + line + "\n")
+		}
+	}
+	fmt.Fprintf(&b, "active_env: %s\n", formatScalar(c.ActiveEnv))
+	fmt.Fprintf(&b, "active_address: %s\n", formatScalar(c.ActiveAddress))
+	return []byte(b.String())
+}
+
+// DefaultClientConfig returns a config for the keystore in dir with the
+// public networks, with testnet active.
+func DefaultClientConfig(dir string) *ClientConfig {
+	c := &ClientConfig{Keystore: filepath.Join(dir, KeystoreFile), ActiveEnv: "testnet"}
+	for _, network := range []string{"mainnet", "testnet", "devnet", "localnet"} {
+		rpc, _ := jsonrpc.GetJSONRPCFullnodeURL(network)
+		c.Envs = append(c.Envs, Env{Alias: network, RPC: rpc})
+	}
+	return c
+}
+
+// LoadClientConfigDir reads client.yaml from dir, or returns
+// DefaultClientConfig when it does not exist yet.
+func LoadClientConfigDir(dir string) (*ClientConfig, error) {
+	c, err := LoadClientConfig(filepath.Join(dir, ClientConfigFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return DefaultClientConfig(dir), nil
+	}
+	return c, err
+}
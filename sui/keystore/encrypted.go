@@ -0,0 +1,141 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrWrongPassphrase is returned when an encrypted keystore cannot be
+// decrypted with the given passphrase.
+var ErrWrongPassphrase = errors.New("wrong passphrase or corrupted keystore")
+
+const (
+	encryptedVersion = 1
+	kdfPBKDF2SHA256  = "pbkdf2-sha256"
+	cipherAES256GCM  = "aes-256-gcm"
+)
+
+// EncryptionIterations is the PBKDF2 iteration count for new encrypted
+// keystores. Existing files keep the count they were written with.
+var EncryptionIterations = 600000
+
+// encryptedFile is the JSON layout of an encrypted keystore. The plaintext is
+// an encryptedPayload.
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Cipher     string `json:"cipher"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// encryptedPayload holds the same entries as sui.keystore and sui.aliases.
+type encryptedPayload struct {
+	Keys    []string     `json:"keys"`
+	Aliases []aliasEntry `json:"aliases"`
+}
+
+// Encrypt returns the keystore encrypted with AES-256-GCM under a key derived
+// from passphrase with PBKDF2-SHA256.
+func (k *Keystore) Encrypt(passphrase string) ([]byte, error) {
+	keys, aliases, err := k.encode()
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := json.Marshal(encryptedPayload{Keys: keys, Aliases: aliases})
+	if err != nil {
+		return nil, err
+	}
+	file := encryptedFile{
+		Version:    encryptedVersion,
+		KDF:        kdfPBKDF2SHA256,
+		Iterations: EncryptionIterations,
+		Salt:       make([]byte, 16),
+		Cipher:     cipherAES256GCM,
+	}
+	if _, err := rand.Read(file.Salt); err != nil {
+		return nil, err
+	}
+	aead, err := file.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	file.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return nil, err
+	}
+	file.Ciphertext = aead.Seal(nil, file.Nonce, plaintext, nil)
+	return json.MarshalIndent(file, "", "  ")
+}
+
+// Decrypt reads a keystore produced by Encrypt.
+func Decrypt(data []byte, passphrase string) (*Keystore, error) {
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid encrypted keystore: %w", err)
+	}
+	if file.Version != encryptedVersion || file.KDF != kdfPBKDF2SHA256 || file.Cipher != cipherAES256GCM {
+		return nil, fmt.Errorf("unsupported encrypted keystore: version %d, %s, %s", file.Version, file.KDF, file.Cipher)
+	}
+	aead, err := file.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid encrypted keystore nonce")
+	}
+	plaintext, err := aead.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	var payload encryptedPayload
+	if err := json.Unmarshal(plaintext, &payload); err != nil {
+		return nil, fmt.Errorf("invalid encrypted keystore payload: %w", err)
+	}
+	keys, _ := json.Marshal(payload.Keys)
+	aliases, _ := json.Marshal(payload.Aliases)
+	return Parse(keys, aliases)
+}
+
+func (f *encryptedFile) aead(passphrase string) (cipher.AEAD, error) {
+	if f.Iterations < 1 {
+		return nil, fmt.Errorf("invalid iteration count: %d", f.Iterations)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, f.Salt, f.Iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// LoadEncryptedFile reads a keystore written by SaveEncryptedFile.
+func LoadEncryptedFile(path, passphrase string) (*Keystore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Decrypt(data, passphrase)
+}
+
+// SaveEncryptedFile writes the keystore encrypted with passphrase, readable
+// only by the owner.
+func (k *Keystore) SaveEncryptedFile(path, passphrase string) error {
+	data, err := k.Encrypt(passphrase)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data)
+}
@@ -0,0 +1,141 @@
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sui-sdks/go-sdks/sui/cryptography"
+)
+
+// aliasEntry is an entry of sui.aliases, which names keys by their flagged
+// base64 public key.
+type aliasEntry struct {
+	Alias           string `json:"alias"`
+	PublicKeyBase64 string `json:"public_key_base64"`
+}
+
+// AliasesPath returns the sui.aliases file the Sui CLI keeps next to a
+// keystore file.
+func AliasesPath(keystorePath string) string {
+	return strings.TrimSuffix(keystorePath, filepath.Ext(keystorePath)) + ".aliases"
+}
+
+// Parse reads the contents of a sui.keystore file and, when not nil, of its
+// sui.aliases file. Keys without an alias get a default one.
+func Parse(keystoreData, aliasesData []byte) (*Keystore, error) {
+	var keys []string
+	if err := json.Unmarshal(keystoreData, &keys); err != nil {
+		return nil, fmt.Errorf("invalid keystore: %w", err)
+	}
+	var aliases []aliasEntry
+	if aliasesData != nil {
+		if err := json.Unmarshal(aliasesData, &aliases); err != nil {
+			return nil, fmt.Errorf("invalid aliases: %w", err)
+		}
+	}
+	byPublicKey := make(map[string]string, len(aliases))
+	for _, a := range aliases {
+		byPublicKey[a.PublicKeyBase64] = a.Alias
+	}
+	ks := New()
+	for i, key := range keys {
+		kp, err := DecodeKeypair(key)
+		if err != nil {
+			return nil, fmt.Errorf("keystore entry %d: %w", i, err)
+		}
+		if _, err := ks.Add(kp, byPublicKey[cryptography.ToSuiPublicKey(kp.GetPublicKey())]); err != nil {
+			return nil, fmt.Errorf("keystore entry %d: %w", i, err)
+		}
+	}
+	return ks, nil
+}
+
+// Marshal returns the contents of the sui.keystore and sui.aliases files.
+func (k *Keystore) Marshal() (keystoreData, aliasesData []byte, err error) {
+	keys, aliases, err := k.encode()
+	if err != nil {
+		return nil, nil, err
+	}
+	if keystoreData, err = json.MarshalIndent(keys, "", "  "); err != nil {
+		return nil, nil, err
+	}
+	if aliasesData, err = json.MarshalIndent(aliases, "", "  "); err != nil {
+		return nil, nil, err
+	}
+	return keystoreData, aliasesData, nil
+}
+
+func (k *Keystore) encode() ([]string, []aliasEntry, error) {
+	entries := k.Entries()
+	keys := make([]string, 0, len(entries))
+	aliases := make([]aliasEntry, 0, len(entries))
+	for _, e := range entries {
+		key, err := EncodeKeypair(e.Keypair)
+		if err != nil {
+			return nil, nil, fmt.Errorf("encode %s: %w", e.Address, err)
+		}
+		keys = append(keys, key)
+		aliases = append(aliases, aliasEntry{Alias: e.Alias, PublicKeyBase64: cryptography.ToSuiPublicKey(e.Keypair.GetPublicKey())})
+	}
+	return keys, aliases, nil
+}
+
+// LoadFile reads a sui.keystore file and its sui.aliases file, if any. A
+// missing keystore file gives an empty keystore.
+func LoadFile(path string) (*Keystore, error) {
+	keystoreData, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	aliasesData, err := os.ReadFile(AliasesPath(path))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return Parse(keystoreData, aliasesData)
+}
+
+// SaveFile writes the keystore to a sui.keystore file and its sui.aliases
+// file, readable only by the owner.
+func (k *Keystore) SaveFile(path string) error {
+	keystoreData, aliasesData, err := k.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := writeFile(path, keystoreData); err != nil {
+		return err
+	}
+	return writeFile(AliasesPath(path), aliasesData)
+}
+
+// writeFile replaces path atomically with data, creating its directory.
+func writeFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
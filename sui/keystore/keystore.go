@@ -0,0 +1,233 @@
+package keystore
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/sui-sdks/go-sdks/sui/cryptography"
+	"github.com/sui-sdks/go-sdks/sui/keypairs/ed25519"
+	"github.com/sui-sdks/go-sdks/sui/keypairs/secp256k1"
+	"github.com/sui-sdks/go-sdks/sui/keypairs/secp256r1"
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+// ErrKeyNotFound is returned when no key matches an address or alias.
+var ErrKeyNotFound = errors.New("key not found")
+
+// aliasPattern is the alias format accepted by the Sui CLI.
+var aliasPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]*$`)
+
+// Entry is a key in a Keystore.
+type Entry struct {
+	Alias   string
+	Address string
+	Keypair cryptography.Keypair
+}
+
+// Keystore holds keypairs in insertion order, each with a unique alias. It is
+// safe for concurrent use.
+type Keystore struct {
+	mu      sync.RWMutex
+	entries []Entry
+}
+
+// New returns an empty keystore.
+func New() *Keystore {
+	return &Keystore{}
+}
+
+// Add stores kp under alias. An empty alias is replaced by one derived from
+// the key scheme and address. Adding a key that is already stored fails.
+func (k *Keystore) Add(kp cryptography.Keypair, alias string) (Entry, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	address := kp.ToSuiAddress()
+	if k.indexOf(address) >= 0 {
+		return Entry{}, fmt.Errorf("key %s already exists", address)
+	}
+	if alias == "" {
+		alias = k.defaultAlias(kp)
+	}
+	if err := k.checkAlias(alias); err != nil {
+		return Entry{}, err
+	}
+	entry := Entry{Alias: alias, Address: address, Keypair: kp}
+	k.entries = append(k.entries, entry)
+	return entry, nil
+}
+
+// Import decodes a secret key, either a keystore entry (base64 flag||key) or
+// a suiprivkey string, and adds it under alias.
+func (k *Keystore) Import(secretKey, alias string) (Entry, error) {
+	var kp cryptography.Keypair
+	var err error
+	if strings.HasPrefix(secretKey, cryptography.SuiPrivateKeyPrefix) {
+		var parsed cryptography.ParsedKeypair
+		if parsed, err = cryptography.DecodeSuiPrivateKey(secretKey); err == nil {
+			kp, err = NewKeypair(parsed.Scheme, parsed.SecretKey)
+		}
+	} else {
+		kp, err = DecodeKeypair(secretKey)
+	}
+	if err != nil {
+		return Entry{}, err
+	}
+	return k.Add(kp, alias)
+}
+
+// Get returns the key for an address or alias.
+func (k *Keystore) Get(addressOrAlias string) (cryptography.Keypair, error) {
+	entry, err := k.Entry(addressOrAlias)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Keypair, nil
+}
+
+// Entry returns the entry for an address or alias.
+func (k *Keystore) Entry(addressOrAlias string) (Entry, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	i := k.find(addressOrAlias)
+	if i < 0 {
+		return Entry{}, fmt.Errorf("%w: %s", ErrKeyNotFound, addressOrAlias)
+	}
+	return k.entries[i], nil
+}
+
+// Entries returns every entry in insertion order.
+func (k *Keystore) Entries() []Entry {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return append([]Entry(nil), k.entries...)
+}
+
+// Addresses returns the address of every key in insertion order.
+func (k *Keystore) Addresses() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	out := make([]string, len(k.entries))
+	for i, e := range k.entries {
+		out[i] = e.Address
+	}
+	return out
+}
+
+// Remove deletes the key for an address or alias.
+func (k *Keystore) Remove(addressOrAlias string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	i := k.find(addressOrAlias)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, addressOrAlias)
+	}
+	k.entries = append(k.entries[:i], k.entries[i+1:]...)
+	return nil
+}
+
+// SetAlias renames the key for an address or alias.
+func (k *Keystore) SetAlias(addressOrAlias, alias string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	i := k.find(addressOrAlias)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, addressOrAlias)
+	}
+	if k.entries[i].Alias == alias {
+		return nil
+	}
+	if err := k.checkAlias(alias); err != nil {
+		return err
+	}
+	k.entries[i].Alias = alias
+	return nil
+}
+
+// find returns the index of the entry for an address or alias, or -1.
+func (k *Keystore) find(addressOrAlias string) int {
+	if strings.HasPrefix(addressOrAlias, "0x") {
+		return k.indexOf(utils.NormalizeSuiAddress(addressOrAlias))
+	}
+	for i, e := range k.entries {
+		if e.Alias == addressOrAlias {
+			return i
+		}
+	}
+	return -1
+}
+
+func (k *Keystore) indexOf(address string) int {
+	for i, e := range k.entries {
+		if e.Address == address {
+			return i
+		}
+	}
+	return -1
+}
+
+func (k *Keystore) checkAlias(alias string) error {
+	if !aliasPattern.MatchString(alias) {
+		return fmt.Errorf("invalid alias %q: must start with a letter and contain only letters, digits, '-', '_' or '.'", alias)
+	}
+	for _, e := range k.entries {
+		if e.Alias == alias {
+			return fmt.Errorf("alias %q already exists", alias)
+		}
+	}
+	return nil
+}
+
+// defaultAlias returns an unused alias such as "ed25519-1a2b3c".
+func (k *Keystore) defaultAlias(kp cryptography.Keypair) string {
+	base := strings.ToLower(string(kp.GetKeyScheme())) + "-" + strings.TrimPrefix(kp.ToSuiAddress(), "0x")[:6]
+	alias := base
+	for n := 2; k.checkAlias(alias) != nil; n++ {
+		alias = fmt.Sprintf("%s-%d", base, n)
+	}
+	return alias
+}
+
+// NewKeypair returns the keypair of scheme for a 32-byte secret key.
+func NewKeypair(scheme cryptography.SignatureScheme, secretKey []byte) (cryptography.Keypair, error) {
+	switch scheme {
+	case cryptography.SchemeED25519:
+		return ed25519.FromSecretKey(secretKey)
+	case cryptography.SchemeSecp256k1:
+		return secp256k1.FromSecretKey(secretKey)
+	case cryptography.SchemeSecp256r1:
+		return secp256r1.FromSecretKey(secretKey)
+	default:
+		return nil, fmt.Errorf("unsupported key scheme %s", scheme)
+	}
+}
+
+// DecodeKeypair decodes a sui.keystore entry: base64 of the scheme flag
+// followed by the 32-byte secret key.
+func DecodeKeypair(value string) (cryptography.Keypair, error) {
+	raw, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore entry: %w", err)
+	}
+	if len(raw) != 1+cryptography.PrivateKeySize {
+		return nil, fmt.Errorf("invalid keystore entry length: %d", len(raw))
+	}
+	scheme, ok := cryptography.SignatureFlagToScheme[raw[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown signature scheme flag: %d", raw[0])
+	}
+	return NewKeypair(scheme, raw[1:])
+}
+
+// EncodeKeypair encodes kp as a sui.keystore entry.
+func EncodeKeypair(kp cryptography.Keypair) (string, error) {
+	parsed, err := cryptography.DecodeSuiPrivateKey(kp.GetSecretKey())
+	if err != nil {
+		return "", err
+	}
+	flag := cryptography.SignatureSchemeToFlag[parsed.Scheme]
+	return base64.StdEncoding.EncodeToString(append([]byte{flag}, parsed.SecretKey...)), nil
+}
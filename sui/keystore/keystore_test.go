@@ -0,0 +1,250 @@
+package keystore
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sui-sdks/go-sdks/sui/cryptography"
+	"github.com/sui-sdks/go-sdks/sui/keypairs/secp256k1"
+)
+
+const keytoolAddress = "0xe69e896ca10f5a77732769803cc2b5707f0ab9d4407afb5e4b4464b89769af14"
+
+// keytoolEntry is the sui.keystore entry of the key behind keytoolAddress.
+func keytoolEntry() string {
+	seed, _ := hex.DecodeString("010c48841eab2cf4f52b52c2e396e0c12541c67ee231d6e3f41c5a52447f9624")
+	return base64.StdEncoding.EncodeToString(append([]byte{0}, seed...))
+}
+
+func TestParseSuiKeystore(t *testing.T) {
+	kp, _ := DecodeKeypair(keytoolEntry())
+	keys := `["` + keytoolEntry() + `"]`
+	aliases := `[{"alias":"main","public_key_base64":"` + cryptography.ToSuiPublicKey(kp.GetPublicKey()) + `"}]`
+	ks, err := Parse([]byte(keys), []byte(aliases))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	for _, lookup := range []string{"main", keytoolAddress, "0x" + strings.ToUpper(keytoolAddress[2:])} {
+		got, err := ks.Get(lookup)
+		if err != nil {
+			t.Fatalf("lookup %s failed: %v", lookup, err)
+		}
+		if got.ToSuiAddress() != keytoolAddress {
+			t.Fatalf("unexpected address %s", got.ToSuiAddress())
+		}
+	}
+	if _, err := ks.Get("missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected ErrKeyNotFound, got %v", err)
+	}
+
+	keystoreData, aliasesData, err := ks.Marshal()
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if !strings.Contains(string(keystoreData), keytoolEntry()) || !strings.Contains(string(aliasesData), `"alias": "main"`) {
+		t.Fatalf("unexpected files:\n%s\n%s", keystoreData, aliasesData)
+	}
+
+	// Keys without an alias get a default one.
+	ks, err = Parse([]byte(keys), nil)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if alias := ks.Entries()[0].Alias; alias != "ed25519-e69e89" {
+		t.Fatalf("unexpected default alias %q", alias)
+	}
+	if _, err := Parse([]byte(`["AAEC"]`), nil); err == nil {
+		t.Fatalf("expected a short entry to be rejected")
+	}
+}
+
+func TestKeystoreAliases(t *testing.T) {
+	ks := New()
+	kp, _ := secp256k1.Generate()
+	if _, err := ks.Add(kp, "1bad"); err == nil {
+		t.Fatalf("expected an invalid alias to be rejected")
+	}
+	if _, err := ks.Add(kp, "alice"); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	if _, err := ks.Add(kp, "bob"); err == nil {
+		t.Fatalf("expected a duplicate key to be rejected")
+	}
+	other, err := ks.Import(keytoolEntry(), "alice")
+	if err == nil {
+		t.Fatalf("expected a duplicate alias to be rejected, got %+v", other)
+	}
+	if _, err := ks.Import(kp.GetSecretKey(), ""); err == nil {
+		t.Fatalf("expected an imported duplicate key to be rejected")
+	}
+	if err := ks.SetAlias("alice", "carol"); err != nil {
+		t.Fatalf("set alias failed: %v", err)
+	}
+	if got, _ := ks.Get("carol"); got == nil || got.ToSuiAddress() != kp.ToSuiAddress() {
+		t.Fatalf("expected the renamed key")
+	}
+	if err := ks.Remove(kp.ToSuiAddress()); err != nil {
+		t.Fatalf("remove failed: %v", err)
+	}
+	if len(ks.Addresses()) != 0 {
+		t.Fatalf("expected an empty keystore")
+	}
+}
+
+func TestKeystoreFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, KeystoreFile)
+	ks, err := LoadFile(path)
+	if err != nil || len(ks.Entries()) != 0 {
+		t.Fatalf("expected an empty keystore for a missing file: %v", err)
+	}
+	if _, err := ks.Import(keytoolEntry(), "main"); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	kp, _ := secp256k1.Generate()
+	ks.Add(kp, "")
+	if err := ks.SaveFile(path); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected owner-only permissions, got %v", info.Mode().Perm())
+	}
+	if _, err := os.Stat(filepath.Join(dir, "sui.aliases")); err != nil {
+		t.Fatalf("expected an aliases file: %v", err)
+	}
+	loaded, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if got := loaded.Addresses(); len(got) != 2 || got[0] != keytoolAddress || got[1] != kp.ToSuiAddress() {
+		t.Fatalf("unexpected addresses %v", got)
+	}
+	if entry, _ := loaded.Entry(kp.ToSuiAddress()); entry.Alias != ks.Entries()[1].Alias {
+		t.Fatalf("alias was not kept: %q", entry.Alias)
+	}
+
+	defer func(n int) { EncryptionIterations = n }(EncryptionIterations)
+	EncryptionIterations = 1000
+	encrypted := filepath.Join(dir, "keys.enc")
+	if err := ks.SaveEncryptedFile(encrypted, "hunter2"); err != nil {
+		t.Fatalf("save encrypted failed: %v", err)
+	}
+	data, _ := os.ReadFile(encrypted)
+	if strings.Contains(string(data), keytoolEntry()) {
+		t.Fatalf("encrypted file contains a plaintext key")
+	}
+	if _, err := LoadEncryptedFile(encrypted, "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("expected ErrWrongPassphrase, got %v", err)
+	}
+	decrypted, err := LoadEncryptedFile(encrypted, "hunter2")
+	if err != nil {
+		t.Fatalf("load encrypted failed: %v", err)
+	}
+	if got, err := decrypted.Get("main"); err != nil || got.ToSuiAddress() != keytoolAddress {
+		t.Fatalf("unexpected decrypted key: %v", err)
+	}
+}
+
+const cliClientConfig = `---
+keystore:
+  File: /home/sui/.sui/sui_config/sui.keystore
+external_keys: ~
+envs:
+- alias: testnet
+  rpc: "https://fullnode.testnet.sui.io:443"
+  ws: ~
+  basic_auth: ~
+- alias: private
+  rpc: "https://rpc.example.com:443"
+  ws: "wss://rpc.example.com:443"
+  basic_auth:
+  - user
+  - "p:ss"
+active_env: testnet
+active_address: "0xe69e896ca10f5a77732769803cc2b5707f0ab9d4407afb5e4b4464b89769af14"
+`
+
+func TestClientConfig(t *testing.T) {
+	c, err := ParseClientConfig([]byte(cliClientConfig))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if c.Keystore != "/home/sui/.sui/sui_config/sui.keystore" || c.ActiveAddress != keytoolAddress || c.ActiveEnv != "testnet" {
+		t.Fatalf("unexpected config %+v", c)
+	}
+	env, ok := c.ActiveEnvironment()
+	if !ok || env.RPC != "https://fullnode.testnet.sui.io:443" || env.WS != "" {
+		t.Fatalf("unexpected active env %+v", env)
+	}
+	private, ok := c.Env("private")
+	if !ok || private.WS != "wss://rpc.example.com:443" {
+		t.Fatalf("unexpected env %+v", private)
+	}
+
+	// Saving keeps the fields that are not modeled.
+	out := string(c.Marshal())
+	for _, want := range []string{"external_keys: ~", "    basic_auth:\n    - user\n    - \"p:ss\"", "active_address: \"" + keytoolAddress + "\"", "  File: /home/sui/.sui/sui_config/sui.keystore"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in:\n%s", want, out)
+		}
+	}
+	again, err := ParseClientConfig([]byte(out))
+	if err != nil {
+		t.Fatalf("reparse failed: %v", err)
+	}
+	if len(again.Envs) != 2 || again.Envs[1].WS != private.WS || string(again.Marshal()) != out {
+		t.Fatalf("round trip mismatch:\n%s\n%s", out, again.Marshal())
+	}
+
+	if err := c.SetActiveEnv("missing"); err == nil {
+		t.Fatalf("expected an unknown env to be rejected")
+	}
+	if err := c.AddEnv(Env{Alias: "testnet", RPC: "http://x"}); err == nil {
+		t.Fatalf("expected a duplicate env to be rejected")
+	}
+}
+
+func TestClientConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	c, err := LoadClientConfigDir(dir)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if env, ok := c.ActiveEnvironment(); !ok || env.Alias != "testnet" {
+		t.Fatalf("expected testnet to be active, got %+v", env)
+	}
+	ks, err := c.LoadKeystore()
+	if err != nil {
+		t.Fatalf("load keystore failed: %v", err)
+	}
+	if _, err := ks.Import(keytoolEntry(), "main"); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if err := c.SetActiveAddress(ks, "main"); err != nil {
+		t.Fatalf("set active address failed: %v", err)
+	}
+	if err := ks.SaveFile(c.Keystore); err != nil {
+		t.Fatalf("save keystore failed: %v", err)
+	}
+	if err := c.Save(filepath.Join(dir, ClientConfigFile)); err != nil {
+		t.Fatalf("save config failed: %v", err)
+	}
+
+	loaded, err := LoadClientConfigDir(dir)
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	loadedKeys, err := loaded.LoadKeystore()
+	if err != nil {
+		t.Fatalf("reload keystore failed: %v", err)
+	}
+	active, err := loadedKeys.Get(loaded.ActiveAddress)
+	if err != nil || active.ToSuiAddress() != keytoolAddress {
+		t.Fatalf("unexpected active key: %v", err)
+	}
+}
@@ -2,6 +2,8 @@ package multisig
 
 import (
 	"encoding/base64"
+	"encoding/hex"
+	"strings"
 	"testing"
 
 	"github.com/sui-sdks/go-sdks/sui/cryptography"
@@ -36,3 +38,23 @@ func TestMultiSigSerializeParse(t *testing.T) {
 		t.Fatalf("verify failed")
 	}
 }
+
+func TestMultiSigAddress(t *testing.T) {
+	ed := strings.Repeat("11", 32)
+	k1 := "02" + strings.Repeat("22", 32)
+	edRaw, _ := hex.DecodeString(ed)
+	k1Raw, _ := hex.DecodeString(k1)
+	ms := MultiSigPublicKey{
+		PublicKeys: []WeightedPublicKey{
+			{Scheme: cryptography.SchemeED25519, PublicKey: base64.StdEncoding.EncodeToString(edRaw), Weight: 1},
+			{Scheme: cryptography.SchemeSecp256k1, PublicKey: base64.StdEncoding.EncodeToString(k1Raw), Weight: 2},
+		},
+		Threshold: 3,
+	}
+	// flag 0x03, threshold u16 LE, then flag || public key || weight per member.
+	preimage, _ := hex.DecodeString("03" + "0300" + "00" + ed + "01" + "01" + k1 + "02")
+	digest := cryptography.Blake2b256(preimage)
+	if got, want := ms.ToSuiAddress(), "0x"+hex.EncodeToString(digest[:]); got != want {
+		t.Fatalf("address mismatch: got %s want %s", got, want)
+	}
+}
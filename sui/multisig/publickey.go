@@ -1,10 +1,10 @@
 package multisig
 
 import (
-	"crypto/sha256"
 	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
-	"fmt"
 
 	"github.com/sui-sdks/go-sdks/sui/cryptography"
 	"github.com/sui-sdks/go-sdks/sui/utils"
@@ -44,9 +44,19 @@ func (m MultiSigPublicKey) Verify(data, signature []byte) bool {
 	return validWeight >= m.Threshold
 }
 
+// ToSuiAddress derives the address as Sui does: the Blake2b-256 hash of the
+// multisig flag, the little-endian u16 threshold, then each member's scheme
+// flag, raw public key and u8 weight.
 func (m MultiSigPublicKey) ToSuiAddress() string {
-	digest := sha256.Sum256(append([]byte{m.Flag()}, m.ToRawBytes()...))
-	return utils.NormalizeSuiAddress(fmt.Sprintf("%x", digest[:]))
+	data := binary.LittleEndian.AppendUint16([]byte{m.Flag()}, uint16(m.Threshold))
+	for _, pk := range m.PublicKeys {
+		raw, _ := base64.StdEncoding.DecodeString(pk.PublicKey)
+		data = append(data, cryptography.SignatureSchemeToFlag[pk.Scheme])
+		data = append(data, raw...)
+		data = append(data, byte(pk.Weight))
+	}
+	digest := cryptography.Blake2b256(data)
+	return utils.NormalizeSuiAddress(hex.EncodeToString(digest[:]))
 }
 
 func (m MultiSigPublicKey) ToBase64() string {
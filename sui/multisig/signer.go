@@ -39,8 +39,8 @@ func (s *Signer) GetPublicKey() cryptography.PublicKey       { return s.PublicKe
 func (s *Signer) ToSuiAddress() string                       { return s.PublicKey.ToSuiAddress() }
 
 func (s *Signer) SignWithIntent(bytes []byte, intent cryptography.IntentScope) (cryptography.SignatureWithBytes, error) {
-	msg := cryptography.MessageWithIntent(intent, bytes)
-	sig, err := s.Sign(msg)
+	digest := cryptography.IntentMessageDigest(intent, bytes)
+	sig, err := s.Sign(digest[:])
 	if err != nil {
 		return cryptography.SignatureWithBytes{}, err
 	}
@@ -60,7 +60,12 @@ func (s *Signer) SignTransaction(bytes []byte) (cryptography.SignatureWithBytes,
 }
 
 func (s *Signer) SignPersonalMessage(bytes []byte) (cryptography.SignatureWithBytes, error) {
-	return s.SignWithIntent(bytes, cryptography.IntentPersonalMessage)
+	sig, err := s.SignWithIntent(cryptography.PersonalMessageBytes(bytes), cryptography.IntentPersonalMessage)
+	if err != nil {
+		return cryptography.SignatureWithBytes{}, err
+	}
+	sig.Bytes = base64.StdEncoding.EncodeToString(bytes)
+	return sig, nil
 }
 
 func (s *Signer) GetSecretKey() string {
@@ -0,0 +1,261 @@
+package transactions
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/sui-sdks/go-sdks/bcs"
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+const ObjectDigestLength = 32
+
+type suiBCS struct {
+	Address                 *bcs.Type
+	ObjectDigest            *bcs.Type
+	SuiObjectRef            *bcs.Type
+	SharedObjectRef         *bcs.Type
+	ObjectArg               *bcs.Type
+	CallArg                 *bcs.Type
+	TypeTag                 *bcs.Type
+	Argument                *bcs.Type
+	ProgrammableMoveCall    *bcs.Type
+	Command                 *bcs.Type
+	ProgrammableTransaction *bcs.Type
+	TransactionKind         *bcs.Type
+	TransactionExpiration   *bcs.Type
+	GasData                 *bcs.Type
+	TransactionDataV1       *bcs.Type
+	TransactionData         *bcs.Type
+	Intent                  *bcs.Type
+	SenderSignedTransaction *bcs.Type
+	SenderSignedData        *bcs.Type
+}
+
+// BCS holds the Sui on-chain type layouts used to serialize transactions.
+var BCS = newSuiBCS()
+
+func newSuiBCS() suiBCS {
+	b := bcs.BCS
+	s := suiBCS{}
+
+	s.Address = b.Bytes(utils.SuiAddressLength).Transform(bcs.TransformOptions{
+		Name: "Address",
+		Input: func(v any) (any, error) {
+			switch t := v.(type) {
+			case string:
+				normalized := utils.NormalizeSuiAddress(t)
+				if !utils.IsValidSuiAddress(normalized) {
+					return nil, fmt.Errorf("invalid Sui address: %s", t)
+				}
+				return hex.DecodeString(normalized[2:])
+			case []byte:
+				return t, nil
+			default:
+				return nil, fmt.Errorf("invalid Sui address: %v", v)
+			}
+		},
+		Output: func(v any) (any, error) {
+			return "0x" + hex.EncodeToString(v.([]byte)), nil
+		},
+	})
+
+	s.ObjectDigest = b.ByteVector().Transform(bcs.TransformOptions{
+		Name: "ObjectDigest",
+		Input: func(v any) (any, error) {
+			str, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid object digest: %v", v)
+			}
+			raw, err := bcs.FromBase58(str)
+			if err != nil {
+				return nil, err
+			}
+			if len(raw) != ObjectDigestLength {
+				return nil, fmt.Errorf("invalid object digest length %d: %s", len(raw), str)
+			}
+			return raw, nil
+		},
+		Output: func(v any) (any, error) {
+			return bcs.ToBase58(v.([]byte)), nil
+		},
+	})
+
+	s.SuiObjectRef = b.Struct("SuiObjectRef", []bcs.Field{
+		{Name: "objectId", Type: s.Address},
+		{Name: "version", Type: b.U64()},
+		{Name: "digest", Type: s.ObjectDigest},
+	})
+
+	s.SharedObjectRef = b.Struct("SharedObjectRef", []bcs.Field{
+		{Name: "objectId", Type: s.Address},
+		{Name: "initialSharedVersion", Type: b.U64()},
+		{Name: "mutable", Type: b.Bool()},
+	})
+
+	s.ObjectArg = b.Enum("ObjectArg", []bcs.Field{
+		{Name: "ImmOrOwnedObject", Type: s.SuiObjectRef},
+		{Name: "SharedObject", Type: s.SharedObjectRef},
+		{Name: "Receiving", Type: s.SuiObjectRef},
+	})
+
+	s.CallArg = b.Enum("CallArg", []bcs.Field{
+		{Name: "Pure", Type: b.Struct("Pure", []bcs.Field{
+			{Name: "bytes", Type: b.ByteVector().Transform(bcs.TransformOptions{
+				Input: func(v any) (any, error) {
+					if str, ok := v.(string); ok {
+						return base64.StdEncoding.DecodeString(str)
+					}
+					return v, nil
+				},
+				Output: func(v any) (any, error) {
+					return base64.StdEncoding.EncodeToString(v.([]byte)), nil
+				},
+			})},
+		})},
+		{Name: "Object", Type: s.ObjectArg},
+	})
+
+	var rawTypeTag *bcs.Type
+	lazyTypeTag := b.Lazy(func() *bcs.Type { return rawTypeTag })
+	structTag := b.Struct("StructTag", []bcs.Field{
+		{Name: "address", Type: s.Address},
+		{Name: "module", Type: b.String()},
+		{Name: "name", Type: b.String()},
+		{Name: "typeParams", Type: b.Vector(lazyTypeTag)},
+	})
+	rawTypeTag = b.Enum("TypeTag", []bcs.Field{
+		{Name: "bool"},
+		{Name: "u8"},
+		{Name: "u64"},
+		{Name: "u128"},
+		{Name: "address"},
+		{Name: "signer"},
+		{Name: "vector", Type: lazyTypeTag},
+		{Name: "struct", Type: structTag},
+		{Name: "u16"},
+		{Name: "u32"},
+		{Name: "u256"},
+	})
+	s.TypeTag = rawTypeTag.Transform(bcs.TransformOptions{
+		Input: func(v any) (any, error) {
+			if str, ok := v.(string); ok {
+				return parseTypeTag(str)
+			}
+			return v, nil
+		},
+		Output: func(v any) (any, error) { return typeTagToString(v) },
+	})
+
+	s.Argument = b.Enum("Argument", []bcs.Field{
+		{Name: "GasCoin"},
+		{Name: "Input", Type: b.U16()},
+		{Name: "Result", Type: b.U16()},
+		{Name: "NestedResult", Type: b.Tuple([]*bcs.Type{b.U16(), b.U16()})},
+	})
+
+	s.ProgrammableMoveCall = b.Struct("ProgrammableMoveCall", []bcs.Field{
+		{Name: "package", Type: s.Address},
+		{Name: "module", Type: b.String()},
+		{Name: "function", Type: b.String()},
+		{Name: "typeArguments", Type: b.Vector(s.TypeTag)},
+		{Name: "arguments", Type: b.Vector(s.Argument)},
+	})
+
+	s.Command = b.Enum("Command", []bcs.Field{
+		{Name: "MoveCall", Type: s.ProgrammableMoveCall},
+		{Name: "TransferObjects", Type: b.Struct("TransferObjects", []bcs.Field{
+			{Name: "objects", Type: b.Vector(s.Argument)},
+			{Name: "address", Type: s.Argument},
+		})},
+		{Name: "SplitCoins", Type: b.Struct("SplitCoins", []bcs.Field{
+			{Name: "coin", Type: s.Argument},
+			{Name: "amounts", Type: b.Vector(s.Argument)},
+		})},
+		{Name: "MergeCoins", Type: b.Struct("MergeCoins", []bcs.Field{
+			{Name: "destination", Type: s.Argument},
+			{Name: "sources", Type: b.Vector(s.Argument)},
+		})},
+		{Name: "Publish", Type: b.Struct("Publish", []bcs.Field{
+			{Name: "modules", Type: b.Vector(b.ByteVector())},
+			{Name: "dependencies", Type: b.Vector(s.Address)},
+		})},
+		{Name: "MakeMoveVec", Type: b.Struct("MakeMoveVec", []bcs.Field{
+			{Name: "type", Type: b.Option(s.TypeTag)},
+			{Name: "elements", Type: b.Vector(s.Argument)},
+		})},
+		{Name: "Upgrade", Type: b.Struct("Upgrade", []bcs.Field{
+			{Name: "modules", Type: b.Vector(b.ByteVector())},
+			{Name: "dependencies", Type: b.Vector(s.Address)},
+			{Name: "package", Type: s.Address},
+			{Name: "ticket", Type: s.Argument},
+		})},
+	})
+
+	s.ProgrammableTransaction = b.Struct("ProgrammableTransaction", []bcs.Field{
+		{Name: "inputs", Type: b.Vector(s.CallArg)},
+		{Name: "commands", Type: b.Vector(s.Command)},
+	})
+
+	s.TransactionKind = b.Enum("TransactionKind", []bcs.Field{
+		{Name: "ProgrammableTransaction", Type: s.ProgrammableTransaction},
+		{Name: "ChangeEpoch"},
+		{Name: "Genesis"},
+		{Name: "ConsensusCommitPrologue"},
+	})
+
+	s.TransactionExpiration = b.Enum("TransactionExpiration", []bcs.Field{
+		{Name: "None"},
+		{Name: "Epoch", Type: b.U64()},
+		{Name: "ValidDuring", Type: b.Struct("ValidDuring", []bcs.Field{
+			{Name: "minEpoch", Type: b.Option(b.U64())},
+			{Name: "maxEpoch", Type: b.Option(b.U64())},
+			{Name: "minTimestamp", Type: b.Option(b.U64())},
+			{Name: "maxTimestamp", Type: b.Option(b.U64())},
+			{Name: "chain", Type: s.ObjectDigest},
+			{Name: "nonce", Type: b.U32()},
+		})},
+	})
+
+	s.GasData = b.Struct("GasData", []bcs.Field{
+		{Name: "payment", Type: b.Vector(s.SuiObjectRef)},
+		{Name: "owner", Type: s.Address},
+		{Name: "price", Type: b.U64()},
+		{Name: "budget", Type: b.U64()},
+	})
+
+	s.TransactionDataV1 = b.Struct("TransactionDataV1", []bcs.Field{
+		{Name: "kind", Type: s.TransactionKind},
+		{Name: "sender", Type: s.Address},
+		{Name: "gasData", Type: s.GasData},
+		{Name: "expiration", Type: s.TransactionExpiration},
+	})
+
+	s.TransactionData = b.Enum("TransactionData", []bcs.Field{
+		{Name: "V1", Type: s.TransactionDataV1},
+	})
+
+	s.Intent = b.Struct("Intent", []bcs.Field{
+		{Name: "scope", Type: b.Enum("IntentScope", []bcs.Field{
+			{Name: "TransactionData"},
+			{Name: "TransactionEffects"},
+			{Name: "CheckpointSummary"},
+			{Name: "PersonalMessage"},
+		})},
+		{Name: "version", Type: b.Enum("IntentVersion", []bcs.Field{{Name: "V0"}})},
+		{Name: "appId", Type: b.Enum("AppId", []bcs.Field{{Name: "Sui"}, {Name: "Narwhal"}, {Name: "Consensus"}})},
+	})
+
+	s.SenderSignedTransaction = b.Struct("SenderSignedTransaction", []bcs.Field{
+		{Name: "intentMessage", Type: b.Struct("IntentMessage<TransactionData>", []bcs.Field{
+			{Name: "intent", Type: s.Intent},
+			{Name: "value", Type: s.TransactionData},
+		})},
+		{Name: "txSignatures", Type: b.Vector(b.ByteVector())},
+	})
+
+	s.SenderSignedData = b.Vector(s.SenderSignedTransaction, bcs.Option{Name: "SenderSignedData"})
+
+	return s
+}
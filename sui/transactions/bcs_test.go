@@ -0,0 +1,185 @@
+package transactions
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/sui-sdks/go-sdks/bcs"
+)
+
+// goldenSplitTransferTx is a hand-assembled TransactionData::V1 for the
+// builder tests. TestChainTransactionGoldens checks real mainnet transactions.
+const goldenSplitTransferTx = "00000200086400000000000000002000000000000000000000000000000000000000000000000000000000000000020202000101000001010300000000010100000000000000000000000000000000000000000000000000000000000000000101000000000000000000000000000000000000000000000000000000000000000507000000000000002001010101010101010101010101010101010101010101010101010101010101010000000000000000000000000000000000000000000000000000000000000001e803000000000000404b4c000000000000"
+
+func TestBuildGoldenSplitAndTransfer(t *testing.T) {
+	tx := NewTransaction()
+	tx.SetSender("0x1")
+	tx.SetGasPrice(1000)
+	tx.SetGasBudget(5_000_000)
+	tx.SetGasPayment([]ObjectRef{{ObjectID: "0x5", Version: "7", Digest: "4vJ9JU1bJJE96FWSJKvHsmmFADCg4gpZQff4P3bkLKi"}})
+
+	w := bcs.NewWriter(nil)
+	_ = w.Write64(100)
+	amount := tx.PureBytes(w.ToBytes())
+	recipient, _ := hex.DecodeString("0000000000000000000000000000000000000000000000000000000000000002")
+	tx.SplitCoins(tx.Gas(), []Argument{amount})
+	tx.TransferObjects([]Argument{NestedResultArgument(0, 0)}, tx.PureBytes(recipient))
+
+	b, err := tx.Build()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+	if got := hex.EncodeToString(b); got != goldenSplitTransferTx {
+		t.Fatalf("unexpected transaction bytes:\n got %s\nwant %s", got, goldenSplitTransferTx)
+	}
+}
+
+func TestBuildMoveCallTypeArguments(t *testing.T) {
+	tx := NewTransaction()
+	tx.SetSender("0x1")
+	tx.SetGasPrice(1)
+	tx.SetGasBudget(1)
+	tx.MoveCall("0x2::coin::zero", nil, []string{"0x2::sui::SUI"})
+
+	b, err := tx.Build()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+	parsed, err := BCS.TransactionData.Parse(b)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	kind := parsed.(map[string]any)["V1"].(map[string]any)["kind"].(map[string]any)
+	cmd := kind["ProgrammableTransaction"].(map[string]any)["commands"].([]any)[0].(map[string]any)
+	call := cmd["MoveCall"].(map[string]any)
+	if call["function"] != "zero" || call["module"] != "coin" {
+		t.Fatalf("unexpected move call: %+v", call)
+	}
+	typeArgs := call["typeArguments"].([]any)
+	if len(typeArgs) != 1 || typeArgs[0] != "0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI" {
+		t.Fatalf("unexpected type arguments: %+v", typeArgs)
+	}
+}
+
+func TestTypeTagEncoding(t *testing.T) {
+	ser, err := BCS.TypeTag.Serialize("vector<0x2::coin::Coin<0x2::sui::SUI>>", nil)
+	if err != nil {
+		t.Fatalf("serialize failed: %v", err)
+	}
+	sui := "0000000000000000000000000000000000000000000000000000000000000002"
+	want := "0607" + sui + "04636f696e04436f696e0107" + sui + "037375690353554900"
+	if got, _ := ser.ToHex(); got != want {
+		t.Fatalf("unexpected type tag bytes:\n got %s\nwant %s", got, want)
+	}
+	parsed, err := ser.Parse()
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if parsed != "vector<0x"+sui+"::coin::Coin<0x"+sui+"::sui::SUI>>" {
+		t.Fatalf("unexpected type tag: %v", parsed)
+	}
+}
+
+func TestBuildRejectsUnresolvedInputs(t *testing.T) {
+	tx := NewTransaction()
+	tx.SetSender("0x1")
+	tx.SetGasPrice(1)
+	tx.SetGasBudget(1)
+	tx.Object("0x2")
+	if _, err := tx.Build(); err == nil {
+		t.Fatalf("expected unresolved input error")
+	}
+}
+
+func TestTransactionFromBytesRoundTrip(t *testing.T) {
+	raw, _ := hex.DecodeString(goldenSplitTransferTx)
+	tx, err := TransactionFromBytes(raw)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	data := tx.GetData()
+	if data.Sender != "0x0000000000000000000000000000000000000000000000000000000000000001" {
+		t.Fatalf("unexpected sender: %s", data.Sender)
+	}
+	if len(data.Inputs) != 2 || len(data.Commands) != 2 {
+		t.Fatalf("unexpected inputs/commands: %d/%d", len(data.Inputs), len(data.Commands))
+	}
+	if data.GasData.Budget != "5000000" || data.GasData.Price != "1000" || len(data.GasData.Payment) != 1 {
+		t.Fatalf("unexpected gas data: %+v", data.GasData)
+	}
+	rebuilt, err := tx.Build()
+	if err != nil {
+		t.Fatalf("rebuild failed: %v", err)
+	}
+	if hex.EncodeToString(rebuilt) != goldenSplitTransferTx {
+		t.Fatalf("round trip mismatch:\n got %x\nwant %s", rebuilt, goldenSplitTransferTx)
+	}
+}
+
+func TestTransactionFromBase64RoundTripAllCommands(t *testing.T) {
+	typ := "0x2::sui::SUI"
+	tx := NewTransaction()
+	tx.SetSender("0xabc")
+	tx.SetGasOwner("0xdef")
+	tx.SetGasPrice(750)
+	tx.SetGasBudget(10_000_000)
+	tx.SetExpiration(map[string]any{"$kind": "Epoch", "Epoch": "42"})
+	tx.Object(Inputs.SharedObjectRef("0x6", false, "1"))
+	tx.Object(Inputs.ReceivingRef(ObjectRef{ObjectID: "0x7", Version: "3", Digest: "4vJ9JU1bJJE96FWSJKvHsmmFADCg4gpZQff4P3bkLKi"}))
+	coin := tx.SplitCoins(tx.Gas(), []Argument{tx.PureBytes([]byte{1, 0, 0, 0, 0, 0, 0, 0})})
+	tx.MergeCoins(tx.Gas(), []Argument{coin})
+	tx.MoveCall("0x2::clock::timestamp_ms", []Argument{InputArgument(0)}, nil)
+	tx.AddCommand(TransactionCommands.MakeMoveVec(&typ, []Argument{ResultArgument(0)}))
+	tx.Publish([][]byte{{0xa1, 0x1c}}, []string{"0x1", "0x2"})
+
+	b64, err := tx.BuildBase64()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+	restored, err := TransactionFrom(b64)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	again, err := restored.BuildBase64()
+	if err != nil {
+		t.Fatalf("rebuild failed: %v", err)
+	}
+	if again != b64 {
+		t.Fatalf("round trip mismatch:\n got %s\nwant %s", again, b64)
+	}
+	if restored.GetData().GasData.Owner != "0x0000000000000000000000000000000000000000000000000000000000000def" {
+		t.Fatalf("unexpected gas owner: %s", restored.GetData().GasData.Owner)
+	}
+}
+
+func TestDecodeRejectsUnknownArguments(t *testing.T) {
+	// The SplitCoins command starts "0202": two commands, then variant 2. Its
+	// coin argument follows; 05 is not an Argument variant.
+	corrupt := strings.Replace(goldenSplitTransferTx, "020200", "020205", 1)
+	b, _ := hex.DecodeString(corrupt)
+	if _, err := TransactionFromBytes(b); err == nil {
+		t.Fatalf("expected an unknown argument variant to be rejected")
+	}
+
+	cmd := map[string]any{"$kind": "TransferObjects", "TransferObjects": map[string]any{
+		"objects": []any{map[string]any{"$kind": "Result", "Result": uint16(0)}},
+		"address": map[string]any{"$kind": "Future"},
+	}}
+	if _, err := commandFromBCS(cmd); err == nil {
+		t.Fatalf("expected an unknown argument kind to be rejected")
+	}
+}
+
+func TestBuildRejectsInvalidArguments(t *testing.T) {
+	tx := NewTransaction()
+	tx.SetSender("0x1")
+	tx.SetGasPrice(1000)
+	tx.SetGasBudget(5_000_000)
+	tx.SetGasPayment([]ObjectRef{{ObjectID: "0x5", Version: "7", Digest: "4vJ9JU1bJJE96FWSJKvHsmmFADCg4gpZQff4P3bkLKi"}})
+	// A forgotten argument must not be encoded as the gas coin.
+	tx.TransferObjects([]Argument{{}}, tx.PureBytes(make([]byte, 32)))
+	if _, err := tx.Build(); err == nil || !strings.Contains(err.Error(), "invalid argument kind") {
+		t.Fatalf("expected an invalid argument error, got %v", err)
+	}
+}
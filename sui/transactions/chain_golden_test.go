@@ -0,0 +1,98 @@
+package transactions
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sui-sdks/go-sdks/bcs"
+)
+
+// chainTransactionsFile holds mainnet transactions as returned by
+// sui_getTransactionBlock with showRawInput. Refresh it with
+//
+//	SUI_IT_ENABLE_FULLNODE=1 SUI_IT_NETWORK=mainnet SUI_IT_WRITE_GOLDENS=1 \
+//	  go test -run TestPTBFullnodeIntegrationGoldenBytes ./sui/transactions
+var chainTransactionsFile = filepath.Join("testdata", "mainnet_transactions.json")
+
+type chainTransaction struct {
+	Digest         string `json:"digest"`
+	RawTransaction string `json:"rawTransaction"`
+}
+
+// errNotProgrammable marks chain transactions that are not programmable
+// transactions, such as system transactions.
+var errNotProgrammable = errors.New("not a programmable transaction")
+
+// checkChainTransaction decodes the TransactionData of a fetched transaction,
+// rebuilds it and checks that the bytes and digest match the chain's.
+func checkChainTransaction(c chainTransaction) error {
+	raw, err := base64.StdEncoding.DecodeString(c.RawTransaction)
+	if err != nil {
+		return fmt.Errorf("decode raw transaction: %w", err)
+	}
+	// SenderSignedData is a one-element vector of the intent message, whose
+	// 3-byte intent precedes the TransactionData, then the signatures.
+	if len(raw) < 4 || raw[0] != 1 {
+		return errors.New("unexpected sender signed data")
+	}
+	reader := bcs.NewReader(raw[4:])
+	if _, err := BCS.TransactionData.Read(reader); err != nil {
+		return fmt.Errorf("%w: %v", errNotProgrammable, err)
+	}
+	want := raw[4 : len(raw)-reader.Remaining()]
+	if got := TransactionDigestFromBytes(want); got != c.Digest {
+		return fmt.Errorf("digest %s, want %s", got, c.Digest)
+	}
+	tx, err := TransactionFromBytes(want)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errNotProgrammable, err)
+	}
+	got, err := tx.Build()
+	if err != nil {
+		return fmt.Errorf("rebuild: %w", err)
+	}
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("rebuilt bytes differ:\n got %x\nwant %x", got, want)
+	}
+	return nil
+}
+
+func TestChainTransactionGoldens(t *testing.T) {
+	data, err := os.ReadFile(chainTransactionsFile)
+	if err != nil {
+		t.Fatalf("read goldens failed: %v", err)
+	}
+	var txs []chainTransaction
+	if err := json.Unmarshal(data, &txs); err != nil {
+		t.Fatalf("unmarshal goldens failed: %v", err)
+	}
+	if len(txs) == 0 {
+		t.Skipf("%s has no transactions yet; see chainTransactionsFile to fetch them", chainTransactionsFile)
+	}
+	for _, c := range txs {
+		if err := checkChainTransaction(c); err != nil {
+			t.Fatalf("%s: %v", c.Digest, err)
+		}
+	}
+}
+
+func TestCheckChainTransaction(t *testing.T) {
+	data, _ := hex.DecodeString(goldenSplitTransferTx)
+	// One intent message with no signatures.
+	raw := append(append([]byte{1, 0, 0, 0}, data...), 0)
+	c := chainTransaction{Digest: goldenSplitTransferDigest, RawTransaction: base64.StdEncoding.EncodeToString(raw)}
+	if err := checkChainTransaction(c); err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	c.Digest = "11111111111111111111111111111111"
+	if err := checkChainTransaction(c); err == nil {
+		t.Fatalf("expected a digest mismatch")
+	}
+}
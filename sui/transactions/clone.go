@@ -0,0 +1,153 @@
+package transactions
+
+import "math/big"
+
+// Clone returns a deep copy of the transaction, including its build plugins
+// and intent resolvers. Changes to the copy, such as those made while it is
+// resolved, never reach t.
+func (t *Transaction) Clone() *Transaction {
+	clone := &Transaction{data: t.data.Clone()}
+	clone.buildPlugins = cloneSlice(t.buildPlugins)
+	if t.intentResolvers != nil {
+		clone.intentResolvers = make(map[string]TransactionPlugin, len(t.intentResolvers))
+		for name, r := range t.intentResolvers {
+			clone.intentResolvers[name] = r
+		}
+	}
+	return clone
+}
+
+// Clone returns a deep copy of the transaction data.
+func (d TransactionData) Clone() TransactionData {
+	out := d
+	out.Expiration = cloneValue(d.Expiration)
+	out.GasData.Payment = cloneSlice(d.GasData.Payment)
+	if d.Inputs != nil {
+		out.Inputs = make([]CallArg, len(d.Inputs))
+		for i, input := range d.Inputs {
+			out.Inputs[i] = input.clone()
+		}
+	}
+	if d.Commands != nil {
+		out.Commands = make([]Command, len(d.Commands))
+		for i, cmd := range d.Commands {
+			out.Commands[i] = cmd.clone()
+		}
+	}
+	return out
+}
+
+func (a CallArg) clone() CallArg {
+	switch {
+	case a.Object != nil:
+		obj := ObjectArg{
+			ImmOrOwnedObject: clonePtr(a.Object.ImmOrOwnedObject),
+			SharedObject:     clonePtr(a.Object.SharedObject),
+			Receiving:        clonePtr(a.Object.Receiving),
+		}
+		return CallArg{Object: &obj}
+	case a.Pure != nil:
+		return CallArg{Pure: &PureArg{Bytes: cloneBytes(a.Pure.Bytes)}}
+	case a.UnresolvedPure != nil:
+		return CallArg{UnresolvedPure: &UnresolvedPure{Value: cloneValue(a.UnresolvedPure.Value)}}
+	case a.UnresolvedObject != nil:
+		obj := UnresolvedObject{
+			ObjectID:             a.UnresolvedObject.ObjectID,
+			Version:              clonePtr(a.UnresolvedObject.Version),
+			Digest:               clonePtr(a.UnresolvedObject.Digest),
+			InitialSharedVersion: clonePtr(a.UnresolvedObject.InitialSharedVersion),
+			Mutable:              clonePtr(a.UnresolvedObject.Mutable),
+		}
+		return CallArg{UnresolvedObject: &obj}
+	}
+	return a
+}
+
+func (c Command) clone() Command {
+	// mapCommandArguments already copies argument slices and intent inputs.
+	out := mapCommandArguments(c, func(arg Argument) Argument { return arg })
+	switch {
+	case out.MoveCall != nil:
+		out.MoveCall.TypeArguments = cloneStrings(c.MoveCall.TypeArguments)
+	case out.MakeMoveVec != nil:
+		out.MakeMoveVec.Type = clonePtr(c.MakeMoveVec.Type)
+	case out.Publish != nil:
+		p := Publish{Modules: cloneModules(c.Publish.Modules), Dependencies: cloneStrings(c.Publish.Dependencies)}
+		out.Publish = &p
+	case out.Upgrade != nil:
+		out.Upgrade.Modules = cloneModules(c.Upgrade.Modules)
+		out.Upgrade.Dependencies = cloneStrings(c.Upgrade.Dependencies)
+	case out.Intent != nil:
+		out.Intent.Data, _ = cloneValue(c.Intent.Data).(map[string]any)
+	}
+	return out
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// cloneSlice copies s, keeping nil and empty slices apart: an empty gas
+// payment, for example, means something different from a missing one.
+func cloneSlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	out := make([]T, len(s))
+	copy(out, s)
+	return out
+}
+
+func cloneBytes(b []byte) []byte { return cloneSlice(b) }
+
+func cloneStrings(s []string) []string { return cloneSlice(s) }
+
+func cloneModules(modules [][]byte) [][]byte {
+	if modules == nil {
+		return nil
+	}
+	out := make([][]byte, len(modules))
+	for i, m := range modules {
+		out[i] = cloneBytes(m)
+	}
+	return out
+}
+
+// cloneValue deep-copies the JSON-like values held in expirations, pure
+// values and intent data.
+func cloneValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		if v == nil {
+			return v
+		}
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = cloneValue(item)
+		}
+		return out
+	case []any:
+		if v == nil {
+			return v
+		}
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = cloneValue(item)
+		}
+		return out
+	case []byte:
+		return cloneBytes(v)
+	case []string:
+		return cloneStrings(v)
+	case *big.Int:
+		if v == nil {
+			return v
+		}
+		return new(big.Int).Set(v)
+	}
+	return v
+}
@@ -0,0 +1,99 @@
+package transactions
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestTransactionCloneIsDeep(t *testing.T) {
+	tx := NewTransaction()
+	tx.SetSender("0x1")
+	tx.SetGasPayment([]ObjectRef{{ObjectID: "0x5", Version: "1", Digest: testDigest}})
+	tx.SetExpiration(map[string]any{"$kind": "Epoch", "Epoch": "7"})
+	version := "3"
+	obj := tx.Object(CallArg{UnresolvedObject: &UnresolvedObject{ObjectID: "0xa", Version: &version}})
+	amount := tx.Pure().U64(10)
+	tx.MoveCall("0x2::m::f", []Argument{obj, amount}, []string{"0x2::sui::SUI"})
+	tx.CoinWithBalance("0x2::sui::SUI", 5)
+	tx.AddIntentResolver("Tip", resolveCoinWithBalance)
+
+	clone := tx.Clone()
+	clone.SetSender("0x2")
+	clone.data.GasData.Payment[0].Version = "9"
+	clone.data.Expiration.(map[string]any)["Epoch"] = "8"
+	*clone.data.Inputs[0].UnresolvedObject.Version = "4"
+	clone.data.Inputs[1].Pure.Bytes[0] = 0xff
+	clone.data.Commands[0].MoveCall.Arguments[0] = GasCoinArgument()
+	clone.data.Commands[0].MoveCall.TypeArguments[0] = "u8"
+	clone.data.Commands[1].Intent.Data["balance"] = "6"
+	clone.AddIntentResolver("Other", resolveCoinWithBalance)
+	clone.AddCommand(TransactionCommands.MergeCoins(GasCoinArgument(), nil))
+
+	data := tx.GetData()
+	switch {
+	case data.Sender == clone.data.Sender:
+		t.Fatalf("sender was shared")
+	case data.GasData.Payment[0].Version != "1":
+		t.Fatalf("gas payment was shared")
+	case data.Expiration.(map[string]any)["Epoch"] != "7":
+		t.Fatalf("expiration was shared")
+	case *data.Inputs[0].UnresolvedObject.Version != "3":
+		t.Fatalf("unresolved object was shared")
+	case data.Inputs[1].Pure.Bytes[0] != 10:
+		t.Fatalf("pure bytes were shared")
+	case data.Commands[0].MoveCall.Arguments[0] != obj || data.Commands[0].MoveCall.TypeArguments[0] != "0x2::sui::SUI":
+		t.Fatalf("move call was shared: %+v", data.Commands[0].MoveCall)
+	case data.Commands[1].Intent.Data["balance"] != "5":
+		t.Fatalf("intent data was shared")
+	case len(data.Commands) != 2 || len(tx.intentResolvers) != 1:
+		t.Fatalf("commands or resolvers were shared")
+	}
+
+	// An empty payment skips coin selection and must not become nil.
+	empty := NewTransaction()
+	empty.SetGasPayment([]ObjectRef{})
+	if payment := empty.Clone().GetData().GasData.Payment; payment == nil || len(payment) != 0 {
+		t.Fatalf("empty gas payment was not preserved: %#v", payment)
+	}
+}
+
+func TestBuildUsesSnapshot(t *testing.T) {
+	template := NewTransaction()
+	template.SetSender("0x1")
+	template.TransferObjects([]Argument{
+		template.Object(Inputs.ObjectRef(ObjectRef{ObjectID: "0xa", Version: "1", Digest: testDigest})),
+		template.CoinWithBalance("0x2::sui::SUI", 100),
+	}, recipient(t, template))
+	before, err := template.Serialize()
+	if err != nil {
+		t.Fatalf("serialize failed: %v", err)
+	}
+
+	results := make([][]byte, 8)
+	errs := make([]error, len(results))
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = template.Build(BuildTransactionOptions{Client: mockCore{}})
+		}(i)
+	}
+	wg.Wait()
+	for i := range results {
+		if errs[i] != nil {
+			t.Fatalf("build %d failed: %v", i, errs[i])
+		}
+		if !bytes.Equal(results[i], results[0]) {
+			t.Fatalf("concurrent builds of one template differ")
+		}
+	}
+	after, _ := template.Serialize()
+	if after != before {
+		t.Fatalf("build modified the template:\nbefore %s\nafter  %s", before, after)
+	}
+	if data := template.GetData(); data.GasData.Payment != nil || data.Commands[0].Intent == nil {
+		t.Fatalf("template was resolved in place: %+v", data)
+	}
+}
@@ -0,0 +1,156 @@
+package transactions
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+const CoinWithBalanceIntent = "CoinWithBalance"
+
+// CoinWithBalance returns a coin of coinType holding exactly amount. The coin
+// is produced at build time: SUI is split from the gas coin, other types are
+// split from the sender's coins, merged first when one coin is not enough.
+// When only the transaction kind is built, for example for sponsorship, SUI
+// also comes from the sender's coins rather than the gas coin.
+func (t *Transaction) CoinWithBalance(coinType string, amount uint64) Argument {
+	if normalized, err := normalizeCoinType(coinType); err == nil {
+		coinType = normalized
+	}
+	return t.AddCommand(Command{Intent: &TransactionIntent{
+		Name:   CoinWithBalanceIntent,
+		Inputs: map[string][]Argument{},
+		Data:   map[string]any{"type": coinType, "balance": strconv.FormatUint(amount, 10)},
+	}})
+}
+
+func normalizeCoinType(coinType string) (string, error) {
+	tag, err := parseTypeTag(coinType)
+	if err != nil {
+		return "", err
+	}
+	return typeTagToString(tag)
+}
+
+type coinWithBalance struct {
+	coinType string
+	balance  uint64
+}
+
+func parseCoinWithBalance(intent *TransactionIntent) (coinWithBalance, error) {
+	coinType, err := normalizeCoinType(toString(intent.Data["type"]))
+	if err != nil {
+		return coinWithBalance{}, fmt.Errorf("invalid CoinWithBalance type: %w", err)
+	}
+	s, err := toU64String(intent.Data["balance"])
+	if err != nil {
+		return coinWithBalance{}, fmt.Errorf("invalid CoinWithBalance balance: %w", err)
+	}
+	balance, _ := strconv.ParseUint(s, 10, 64)
+	return coinWithBalance{coinType: coinType, balance: balance}, nil
+}
+
+// resolveCoinWithBalance replaces CoinWithBalance intents with SplitCoins
+// commands, loading and merging the sender's coins of each type once.
+func resolveCoinWithBalance(transactionData *TransactionData, options BuildTransactionOptions, next func() error) error {
+	sui, _ := normalizeCoinType(suiCoinType)
+	useGasCoin := !options.OnlyTransactionKind
+
+	totals := map[string]*big.Int{}
+	var types []string
+	for _, cmd := range transactionData.Commands {
+		if cmd.Intent == nil || cmd.Intent.Name != CoinWithBalanceIntent {
+			continue
+		}
+		c, err := parseCoinWithBalance(cmd.Intent)
+		if err != nil {
+			return err
+		}
+		if c.balance == 0 || (c.coinType == sui && useGasCoin) {
+			continue
+		}
+		if totals[c.coinType] == nil {
+			totals[c.coinType] = new(big.Int)
+			types = append(types, c.coinType)
+		}
+		totals[c.coinType].Add(totals[c.coinType], new(big.Int).SetUint64(c.balance))
+	}
+
+	coins := map[string][]ObjectRef{}
+	if len(types) > 0 {
+		if options.Client == nil {
+			return errors.New("no client passed to resolve CoinWithBalance")
+		}
+		if transactionData.Sender == "" {
+			return errors.New("CoinWithBalance requires a transaction sender")
+		}
+		exclude := map[string]bool{}
+		for _, input := range transactionData.Inputs {
+			switch {
+			case input.Object != nil && input.Object.ImmOrOwnedObject != nil:
+				exclude[utils.NormalizeSuiObjectID(input.Object.ImmOrOwnedObject.ObjectID)] = true
+			case input.UnresolvedObject != nil:
+				exclude[utils.NormalizeSuiObjectID(input.UnresolvedObject.ObjectID)] = true
+			}
+		}
+		for _, coinType := range types {
+			selected, total, err := selectCoins(options.Context(), options.Client, transactionData.Sender, coinType, totals[coinType], exclude, 0)
+			if err != nil {
+				return err
+			}
+			if total.Cmp(totals[coinType]) < 0 {
+				return fmt.Errorf("insufficient balance of %s for address %s: need %s, found %s", coinType, transactionData.Sender, totals[coinType], total)
+			}
+			coins[coinType] = selected
+		}
+	}
+
+	primaries := map[string]Argument{}
+	for i := 0; i < len(transactionData.Commands); i++ {
+		intent := transactionData.Commands[i].Intent
+		if intent == nil || intent.Name != CoinWithBalanceIntent {
+			continue
+		}
+		c, _ := parseCoinWithBalance(intent)
+		if c.balance == 0 {
+			zero := TransactionCommands.MoveCall("0x2::coin::zero", nil, []string{c.coinType})
+			transactionData.ReplaceCommand(i, []Command{zero}, ResultArgument(uint16(i)))
+			continue
+		}
+
+		var replacement []Command
+		source := GasCoinArgument()
+		if c.coinType != sui || !useGasCoin {
+			primary, ok := primaries[c.coinType]
+			if !ok {
+				refs := coins[c.coinType]
+				args := make([]Argument, len(refs))
+				for j, ref := range refs {
+					transactionData.Inputs = append(transactionData.Inputs, Inputs.ObjectRef(ref))
+					args[j] = InputArgument(uint16(len(transactionData.Inputs) - 1))
+				}
+				primary = args[0]
+				if len(args) > 1 {
+					replacement = append(replacement, TransactionCommands.MergeCoins(primary, args[1:]))
+				}
+				primaries[c.coinType] = primary
+			}
+			source = primary
+		}
+		amount, err := serializePure("u64", c.balance)
+		if err != nil {
+			return err
+		}
+		transactionData.Inputs = append(transactionData.Inputs, Inputs.Pure(amount))
+		amountArg := InputArgument(uint16(len(transactionData.Inputs) - 1))
+		replacement = append(replacement, TransactionCommands.SplitCoins(source, []Argument{amountArg}))
+
+		split := i + len(replacement) - 1
+		transactionData.ReplaceCommand(i, replacement, NestedResultArgument(uint16(split), 0))
+		i = split
+	}
+	return next()
+}
@@ -0,0 +1,116 @@
+package transactions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+func TestCoinWithBalance(t *testing.T) {
+	usdc := utils.NormalizeSuiAddress("0x42") + "::usdc::USDC"
+	client := &resolverClient{coins: map[string][]any{
+		usdc: {coin("0xc1", "100"), coin("0xc2", "80"), coin("0xc3", "90"), coin("0xc4", "1000")},
+	}}
+	tx := NewTransaction()
+	tx.SetSender("0x1")
+	tx.SetGasPrice(1000)
+	tx.SetGasBudget(1000000)
+	tx.SetGasPayment([]ObjectRef{{ObjectID: "0x100", Version: "1", Digest: testDigest}})
+	recipient, _ := tx.Pure().Address("0x2")
+	sui := tx.CoinWithBalance("0x2::sui::SUI", 10)
+	first := tx.CoinWithBalance("0x42::usdc::USDC", 150)
+	second := tx.CoinWithBalance("0x42::usdc::USDC", 50)
+	tx.TransferObjects([]Argument{sui, first, second}, recipient)
+	zero := tx.CoinWithBalance("0x42::usdc::USDC", 0)
+	tx.TransferObjects([]Argument{zero}, recipient)
+
+	serialized, err := tx.Serialize()
+	if err != nil || !strings.Contains(serialized, `"$kind":"$Intent"`) {
+		t.Fatalf("expected intent in serialized transaction: %s %v", serialized, err)
+	}
+	if _, err := tx.Build(); err == nil {
+		t.Fatalf("expected unresolved intent error without a client")
+	}
+
+	built, err := tx.Build(BuildTransactionOptions{Client: client})
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+	if len(tx.GetData().Commands) != 6 {
+		t.Fatalf("build must not resolve the transaction's own intents")
+	}
+	resolved, err := TransactionFromBytes(built)
+	if err != nil {
+		t.Fatalf("parse built transaction: %v", err)
+	}
+	data := resolved.GetData()
+	kinds := make([]string, len(data.Commands))
+	for i, cmd := range data.Commands {
+		kinds[i] = cmd.Kind()
+	}
+	want := "SplitCoins,MergeCoins,SplitCoins,SplitCoins,TransferObjects,MoveCall,TransferObjects"
+	if got := strings.Join(kinds, ","); got != want {
+		t.Fatalf("unexpected commands: got %s want %s", got, want)
+	}
+	if data.Commands[0].SplitCoins.Coin != GasCoinArgument() {
+		t.Fatalf("SUI must be split from the gas coin: %+v", data.Commands[0].SplitCoins)
+	}
+	merge := data.Commands[1].MergeCoins
+	if len(merge.Sources) != 2 || data.Commands[2].SplitCoins.Coin != merge.Destination || data.Commands[3].SplitCoins.Coin != merge.Destination {
+		t.Fatalf("USDC splits must share the merged coin: %+v", data.Commands[1:4])
+	}
+	if ref := data.Inputs[merge.Destination.Index].Object.ImmOrOwnedObject; ref == nil || ref.ObjectID != utils.NormalizeSuiObjectID("0xc1") {
+		t.Fatalf("unexpected primary coin: %+v", data.Inputs[merge.Destination.Index])
+	}
+	transfer := data.Commands[4].TransferObjects.Objects
+	if transfer[0] != NestedResultArgument(0, 0) || transfer[1] != NestedResultArgument(2, 0) || transfer[2] != NestedResultArgument(3, 0) {
+		t.Fatalf("intent results were not remapped: %+v", transfer)
+	}
+	if call := data.Commands[5].MoveCall; call.Function != "zero" || call.TypeArguments[0] != usdc {
+		t.Fatalf("zero balance must use coin::zero: %+v", call)
+	}
+	if got := data.Commands[6].TransferObjects.Objects[0]; got != ResultArgument(5) {
+		t.Fatalf("zero coin result was not remapped: %+v", got)
+	}
+	if got := pureInputHex(t, resolved, data.Commands[2].SplitCoins.Amounts[0]); got != "9600000000000000" {
+		t.Fatalf("unexpected split amount: %s", got)
+	}
+}
+
+func TestCoinWithBalanceKindOnlyAndInsufficient(t *testing.T) {
+	sui := utils.NormalizeSuiAddress("0x2") + "::sui::SUI"
+	client := &resolverClient{coins: map[string][]any{sui: {coin("0xd1", "500")}}}
+	tx := NewTransaction()
+	tx.SetSender("0x1")
+	tx.TransferObjects([]Argument{tx.CoinWithBalance("0x2::sui::SUI", 300)}, recipient(t, tx))
+	kind, err := tx.BuildKind(BuildTransactionOptions{Client: client})
+	if err != nil {
+		t.Fatalf("build kind failed: %v", err)
+	}
+	resolved, err := TransactionFromKind(kind)
+	if err != nil {
+		t.Fatalf("parse built kind: %v", err)
+	}
+	if split := resolved.GetData().Commands[0].SplitCoins; split == nil || split.Coin.Kind != ArgumentInput {
+		t.Fatalf("kind-only builds must not use the gas coin: %+v", resolved.GetData().Commands[0])
+	}
+
+	poor := NewTransaction()
+	poor.SetSender("0x1")
+	poor.CoinWithBalance("0x2::sui::SUI", 600)
+	if _, err := poor.BuildKind(BuildTransactionOptions{Client: client}); err == nil || !strings.Contains(err.Error(), "insufficient balance") {
+		t.Fatalf("expected insufficient balance error, got %v", err)
+	}
+
+	intent := NewTransaction()
+	intent.CoinWithBalance("0x2::sui::SUI", 1)
+	serialized, err := intent.Serialize()
+	if err != nil {
+		t.Fatalf("serialize failed: %v", err)
+	}
+	restored, err := TransactionFrom(serialized)
+	if err != nil || restored.GetData().Commands[0].Intent == nil {
+		t.Fatalf("intent did not round-trip through JSON: %v", err)
+	}
+}
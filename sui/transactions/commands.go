@@ -1,15 +1,221 @@
 package transactions
 
 import (
-	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/sui-sdks/go-sdks/sui/utils"
 )
 
-type Argument map[string]any
+type ArgumentKind string
 
-type Command map[string]any
+const (
+	ArgumentGasCoin      ArgumentKind = "GasCoin"
+	ArgumentInput        ArgumentKind = "Input"
+	ArgumentResult       ArgumentKind = "Result"
+	ArgumentNestedResult ArgumentKind = "NestedResult"
+)
+
+// Argument references a value inside a programmable transaction. Index is the
+// input or command index; ResultIndex is only used by NestedResult.
+type Argument struct {
+	Kind        ArgumentKind
+	Index       uint16
+	ResultIndex uint16
+}
+
+func GasCoinArgument() Argument { return Argument{Kind: ArgumentGasCoin} }
+func InputArgument(index uint16) Argument {
+	return Argument{Kind: ArgumentInput, Index: index}
+}
+func ResultArgument(index uint16) Argument {
+	return Argument{Kind: ArgumentResult, Index: index}
+}
+func NestedResultArgument(index, resultIndex uint16) Argument {
+	return Argument{Kind: ArgumentNestedResult, Index: index, ResultIndex: resultIndex}
+}
+
+// Nested returns the i-th value produced by a Result argument.
+func (a Argument) Nested(i uint16) Argument {
+	return NestedResultArgument(a.Index, i)
+}
+
+func (a Argument) MarshalJSON() ([]byte, error) {
+	switch a.Kind {
+	case ArgumentGasCoin:
+		return marshalEnum(string(a.Kind), true)
+	case ArgumentInput, ArgumentResult:
+		return marshalEnum(string(a.Kind), a.Index)
+	case ArgumentNestedResult:
+		return marshalEnum(string(a.Kind), [2]uint16{a.Index, a.ResultIndex})
+	default:
+		return nil, fmt.Errorf("invalid argument kind: %q", a.Kind)
+	}
+}
+
+func (a *Argument) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		GasCoin      *bool      `json:"GasCoin"`
+		Input        *uint16    `json:"Input"`
+		Result       *uint16    `json:"Result"`
+		NestedResult *[2]uint16 `json:"NestedResult"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	switch {
+	case aux.GasCoin != nil:
+		*a = GasCoinArgument()
+	case aux.Input != nil:
+		*a = InputArgument(*aux.Input)
+	case aux.Result != nil:
+		*a = ResultArgument(*aux.Result)
+	case aux.NestedResult != nil:
+		*a = NestedResultArgument(aux.NestedResult[0], aux.NestedResult[1])
+	default:
+		return fmt.Errorf("invalid argument: %s", data)
+	}
+	return nil
+}
+
+type MoveCall struct {
+	Package       string     `json:"package"`
+	Module        string     `json:"module"`
+	Function      string     `json:"function"`
+	TypeArguments []string   `json:"typeArguments"`
+	Arguments     []Argument `json:"arguments"`
+}
+
+type TransferObjects struct {
+	Objects []Argument `json:"objects"`
+	Address Argument   `json:"address"`
+}
+
+type SplitCoins struct {
+	Coin    Argument   `json:"coin"`
+	Amounts []Argument `json:"amounts"`
+}
+
+type MergeCoins struct {
+	Destination Argument   `json:"destination"`
+	Sources     []Argument `json:"sources"`
+}
+
+type Publish struct {
+	Modules      [][]byte `json:"modules"`
+	Dependencies []string `json:"dependencies"`
+}
+
+type MakeMoveVec struct {
+	Type     *string    `json:"type"`
+	Elements []Argument `json:"elements"`
+}
+
+type Upgrade struct {
+	Modules      [][]byte `json:"modules"`
+	Dependencies []string `json:"dependencies"`
+	Package      string   `json:"package"`
+	Ticket       Argument `json:"ticket"`
+}
+
+// TransactionIntent is a placeholder command that an intent resolver replaces
+// with concrete commands at build time, such as CoinWithBalance.
+type TransactionIntent struct {
+	Name   string                `json:"name"`
+	Inputs map[string][]Argument `json:"inputs"`
+	Data   map[string]any        `json:"data"`
+}
+
+// Command is a programmable transaction command. Exactly one field is set.
+type Command struct {
+	MoveCall        *MoveCall          `json:"MoveCall,omitempty"`
+	TransferObjects *TransferObjects   `json:"TransferObjects,omitempty"`
+	SplitCoins      *SplitCoins        `json:"SplitCoins,omitempty"`
+	MergeCoins      *MergeCoins        `json:"MergeCoins,omitempty"`
+	Publish         *Publish           `json:"Publish,omitempty"`
+	MakeMoveVec     *MakeMoveVec       `json:"MakeMoveVec,omitempty"`
+	Upgrade         *Upgrade           `json:"Upgrade,omitempty"`
+	Intent          *TransactionIntent `json:"$Intent,omitempty"`
+}
+
+func (c Command) Kind() string {
+	switch {
+	case c.MoveCall != nil:
+		return "MoveCall"
+	case c.TransferObjects != nil:
+		return "TransferObjects"
+	case c.SplitCoins != nil:
+		return "SplitCoins"
+	case c.MergeCoins != nil:
+		return "MergeCoins"
+	case c.Publish != nil:
+		return "Publish"
+	case c.MakeMoveVec != nil:
+		return "MakeMoveVec"
+	case c.Upgrade != nil:
+		return "Upgrade"
+	case c.Intent != nil:
+		return "$Intent"
+	default:
+		return ""
+	}
+}
+
+func (c Command) MarshalJSON() ([]byte, error) {
+	switch c.Kind() {
+	case "MoveCall":
+		return marshalEnum("MoveCall", c.MoveCall)
+	case "TransferObjects":
+		return marshalEnum("TransferObjects", c.TransferObjects)
+	case "SplitCoins":
+		return marshalEnum("SplitCoins", c.SplitCoins)
+	case "MergeCoins":
+		return marshalEnum("MergeCoins", c.MergeCoins)
+	case "Publish":
+		return marshalEnum("Publish", c.Publish)
+	case "MakeMoveVec":
+		return marshalEnum("MakeMoveVec", c.MakeMoveVec)
+	case "Upgrade":
+		return marshalEnum("Upgrade", c.Upgrade)
+	case "$Intent":
+		return marshalEnum("$Intent", c.Intent)
+	default:
+		return nil, fmt.Errorf("empty command")
+	}
+}
+
+// Arguments returns every argument referenced by the command, in BCS field order.
+func (c Command) Arguments() []Argument {
+	switch {
+	case c.MoveCall != nil:
+		return c.MoveCall.Arguments
+	case c.TransferObjects != nil:
+		return append(append([]Argument{}, c.TransferObjects.Objects...), c.TransferObjects.Address)
+	case c.SplitCoins != nil:
+		return append([]Argument{c.SplitCoins.Coin}, c.SplitCoins.Amounts...)
+	case c.MergeCoins != nil:
+		return append([]Argument{c.MergeCoins.Destination}, c.MergeCoins.Sources...)
+	case c.MakeMoveVec != nil:
+		return c.MakeMoveVec.Elements
+	case c.Upgrade != nil:
+		return []Argument{c.Upgrade.Ticket}
+	case c.Intent != nil:
+		names := make([]string, 0, len(c.Intent.Inputs))
+		for name := range c.Intent.Inputs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var out []Argument
+		for _, name := range names {
+			out = append(out, c.Intent.Inputs[name]...)
+		}
+		return out
+	default:
+		return nil
+	}
+}
 
 const (
 	UpgradePolicyCompatible = 0
@@ -24,12 +230,13 @@ var TransactionCommands = struct {
 	MergeCoins      func(destination Argument, sources []Argument) Command
 	Publish         func(modules [][]byte, dependencies []string) Command
 	MakeMoveVec     func(typ *string, elements []Argument) Command
+	Upgrade         func(modules [][]byte, dependencies []string, packageID string, ticket Argument) Command
 }{
 	MoveCall: func(target string, args []Argument, typeArgs []string) Command {
 		parts := strings.Split(target, "::")
 		pkg, mod, fn := "", "", ""
 		if len(parts) > 0 {
-			pkg = parts[0]
+			pkg = utils.NormalizeSuiObjectID(parts[0])
 		}
 		if len(parts) > 1 {
 			mod = parts[1]
@@ -37,33 +244,38 @@ var TransactionCommands = struct {
 		if len(parts) > 2 {
 			fn = parts[2]
 		}
-		return Command{"$kind": "MoveCall", "MoveCall": map[string]any{"package": pkg, "module": mod, "function": fn, "arguments": args, "typeArguments": typeArgs}}
+		if args == nil {
+			args = []Argument{}
+		}
+		if typeArgs == nil {
+			typeArgs = []string{}
+		}
+		return Command{MoveCall: &MoveCall{Package: pkg, Module: mod, Function: fn, TypeArguments: typeArgs, Arguments: args}}
 	},
 	TransferObjects: func(objects []Argument, address Argument) Command {
-		return Command{"$kind": "TransferObjects", "TransferObjects": map[string]any{"objects": objects, "address": address}}
+		return Command{TransferObjects: &TransferObjects{Objects: objects, Address: address}}
 	},
 	SplitCoins: func(coin Argument, amounts []Argument) Command {
-		return Command{"$kind": "SplitCoins", "SplitCoins": map[string]any{"coin": coin, "amounts": amounts}}
+		return Command{SplitCoins: &SplitCoins{Coin: coin, Amounts: amounts}}
 	},
 	MergeCoins: func(destination Argument, sources []Argument) Command {
-		return Command{"$kind": "MergeCoins", "MergeCoins": map[string]any{"destination": destination, "sources": sources}}
+		return Command{MergeCoins: &MergeCoins{Destination: destination, Sources: sources}}
 	},
 	Publish: func(modules [][]byte, dependencies []string) Command {
-		mods := make([]string, len(modules))
-		for i := range modules {
-			mods[i] = base64.StdEncoding.EncodeToString(modules[i])
-		}
 		deps := make([]string, len(dependencies))
 		for i := range dependencies {
 			deps[i] = utils.NormalizeSuiObjectID(dependencies[i])
 		}
-		return Command{"$kind": "Publish", "Publish": map[string]any{"modules": mods, "dependencies": deps}}
+		return Command{Publish: &Publish{Modules: modules, Dependencies: deps}}
 	},
 	MakeMoveVec: func(typ *string, elements []Argument) Command {
-		var t any
-		if typ != nil {
-			t = *typ
+		return Command{MakeMoveVec: &MakeMoveVec{Type: typ, Elements: elements}}
+	},
+	Upgrade: func(modules [][]byte, dependencies []string, packageID string, ticket Argument) Command {
+		deps := make([]string, len(dependencies))
+		for i := range dependencies {
+			deps[i] = utils.NormalizeSuiObjectID(dependencies[i])
 		}
-		return Command{"$kind": "MakeMoveVec", "MakeMoveVec": map[string]any{"type": t, "elements": elements}}
+		return Command{Upgrade: &Upgrade{Modules: modules, Dependencies: deps, Package: utils.NormalizeSuiObjectID(packageID), Ticket: ticket}}
 	},
 }
@@ -4,91 +4,396 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/sui-sdks/go-sdks/sui/utils"
 )
 
 const (
 	GasSafeOverhead = int64(1000)
 	MaxGas          = int64(50_000_000_000)
+
+	maxObjectsPerFetch   = 50
+	maxGasPaymentObjects = 256
+
+	suiCoinType = "0x2::sui::SUI"
 )
 
 func CoreClientResolveTransaction(transactionData *TransactionData, options BuildTransactionOptions) error {
 	if options.Client == nil {
 		return errors.New("missing core client")
 	}
-	if err := normalizeInputs(transactionData); err != nil {
+	ctx := options.Context()
+	params, err := moveCallParameters(ctx, transactionData, options.Client, func(call *MoveCall) bool {
+		for _, arg := range call.Arguments {
+			if arg.Kind == ArgumentInput && int(arg.Index) < len(transactionData.Inputs) {
+				input := transactionData.Inputs[arg.Index]
+				if input.UnresolvedPure != nil || input.UnresolvedObject != nil {
+					return true
+				}
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return err
+	}
+	if err := normalizeInputs(transactionData, params); err != nil {
 		return err
 	}
-	if err := resolveObjectReferences(transactionData, options.Client); err != nil {
+	if err := resolveObjectReferences(ctx, transactionData, options.Client, params); err != nil {
 		return err
 	}
 	if !options.OnlyTransactionKind {
-		if err := setGasData(transactionData, options.Client); err != nil {
+		if err := setGasData(ctx, transactionData, options.Client); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func normalizeInputs(transactionData *TransactionData) error {
+// normalizeInputs BCS-encodes UnresolvedPure inputs using the parameter types
+// of the MoveCalls that consume them. params holds the normalized parameter
+// types for each MoveCall, keyed by command index.
+func normalizeInputs(transactionData *TransactionData, params map[int][]any) error {
 	for i, input := range transactionData.Inputs {
-		if input["$kind"] == "UnresolvedPure" {
-			transactionData.Inputs[i] = Inputs.Pure([]byte(toString(input["UnresolvedPure"])))
+		if input.UnresolvedPure == nil {
+			continue
+		}
+		if b, ok := input.UnresolvedPure.Value.([]byte); ok {
+			transactionData.Inputs[i] = Inputs.Pure(b)
+		}
+	}
+	for ci, cmd := range transactionData.Commands {
+		if cmd.MoveCall == nil {
+			continue
+		}
+		types := params[ci]
+		for ai, arg := range cmd.MoveCall.Arguments {
+			if arg.Kind != ArgumentInput || int(arg.Index) >= len(transactionData.Inputs) {
+				continue
+			}
+			input := transactionData.Inputs[arg.Index]
+			if input.UnresolvedPure == nil {
+				continue
+			}
+			if ai >= len(types) {
+				return fmt.Errorf("unable to determine the type of input %d: argument %d of command %d has no parameter type", arg.Index, ai, ci)
+			}
+			typ, ok := pureTypeFromMove(types[ai], cmd.MoveCall.TypeArguments)
+			if !ok {
+				return fmt.Errorf("input %d is passed to a non-pure parameter of command %d: %v", arg.Index, ci, types[ai])
+			}
+			b, err := serializePure(typ, input.UnresolvedPure.Value)
+			if err != nil {
+				return fmt.Errorf("invalid %s value for input %d: %w", typ, arg.Index, err)
+			}
+			transactionData.Inputs[arg.Index] = Inputs.Pure(b)
 		}
 	}
 	return nil
 }
 
-func resolveObjectReferences(transactionData *TransactionData, client CoreClient) error {
+func resolveObjectReferences(ctx context.Context, transactionData *TransactionData, client CoreClient, params map[int][]any) error {
+	ids := []string{}
+	seen := map[string]bool{}
 	for i, input := range transactionData.Inputs {
-		if input["$kind"] != "UnresolvedObject" {
+		obj := input.UnresolvedObject
+		if obj == nil {
 			continue
 		}
-		payload, _ := input["UnresolvedObject"].(map[string]any)
-		objectID, _ := payload["objectId"].(string)
-		if objectID == "" {
+		if obj.ObjectID == "" {
 			return fmt.Errorf("invalid unresolved object at input %d", i)
 		}
-		transactionData.Inputs[i] = map[string]any{
-			"$kind": "Object",
-			"Object": map[string]any{
-				"$kind": "ImmOrOwnedObject",
-				"ImmOrOwnedObject": map[string]any{
-					"objectId": objectID,
-					"version":  payload["version"],
-					"digest":   payload["digest"],
-				},
-			},
+		if obj.InitialSharedVersion != nil || (obj.Version != nil && obj.Digest != nil) {
+			continue
+		}
+		id := utils.NormalizeSuiObjectID(obj.ObjectID)
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	objects, err := fetchObjects(ctx, client, ids)
+	if err != nil {
+		return err
+	}
+	for i, input := range transactionData.Inputs {
+		obj := input.UnresolvedObject
+		if obj == nil {
+			continue
+		}
+		id := utils.NormalizeSuiObjectID(obj.ObjectID)
+		ref := ObjectRef{ObjectID: id}
+		if obj.Version != nil {
+			ref.Version = *obj.Version
+		}
+		if obj.Digest != nil {
+			ref.Digest = *obj.Digest
+		}
+		initialSharedVersion := obj.InitialSharedVersion
+		if fetched, ok := objects[id]; ok {
+			ref = fetched.ref
+			if fetched.initialSharedVersion != nil {
+				initialSharedVersion = fetched.initialSharedVersion
+			}
+		}
+		switch {
+		case initialSharedVersion != nil:
+			mutable := (obj.Mutable != nil && *obj.Mutable) || isUsedAsMutable(transactionData, i, params)
+			transactionData.Inputs[i] = Inputs.SharedObjectRef(id, mutable, *initialSharedVersion)
+		case isUsedAsReceiving(transactionData, i, params):
+			transactionData.Inputs[i] = Inputs.ReceivingRef(ref)
+		default:
+			transactionData.Inputs[i] = Inputs.ObjectRef(ref)
 		}
 	}
-	_ = client
 	return nil
 }
 
-func setGasData(transactionData *TransactionData, client CoreClient) error {
+type resolvedObject struct {
+	ref                  ObjectRef
+	initialSharedVersion *string
+}
+
+// fetchObjects loads the current reference and ownership of each object with
+// sui_multiGetObjects, in batches of maxObjectsPerFetch.
+func fetchObjects(ctx context.Context, client CoreClient, ids []string) (map[string]resolvedObject, error) {
+	out := map[string]resolvedObject{}
+	invalid := []string{}
+	for start := 0; start < len(ids); start += maxObjectsPerFetch {
+		chunk := ids[start:min(start+maxObjectsPerFetch, len(ids))]
+		var objects []map[string]any
+		if err := client.Call(ctx, "sui_multiGetObjects", []any{chunk, map[string]any{"showOwner": true}}, &objects); err != nil {
+			return nil, err
+		}
+		if len(objects) != len(chunk) {
+			return nil, fmt.Errorf("expected %d objects, got %d", len(chunk), len(objects))
+		}
+		for i, id := range chunk {
+			data, _ := objects[i]["data"].(map[string]any)
+			if data == nil {
+				invalid = append(invalid, fmt.Sprintf("%s (%v)", id, objects[i]["error"]))
+				continue
+			}
+			version, err := toU64String(data["version"])
+			if err != nil {
+				return nil, fmt.Errorf("invalid version for object %s: %w", id, err)
+			}
+			obj := resolvedObject{ref: ObjectRef{ObjectID: id, Version: version, Digest: toString(data["digest"])}}
+			if v, ok := initialSharedVersionFromOwner(data["owner"]); ok {
+				obj.initialSharedVersion = &v
+			}
+			out[id] = obj
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("the following input objects are invalid: %s", strings.Join(invalid, ", "))
+	}
+	return out, nil
+}
+
+// initialSharedVersionFromOwner extracts the version to use in a SharedObject
+// input for shared and consensus-address-owned objects.
+func initialSharedVersionFromOwner(owner any) (string, bool) {
+	m, ok := owner.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	if shared, ok := m["Shared"].(map[string]any); ok {
+		v, err := toU64String(shared["initial_shared_version"])
+		return v, err == nil
+	}
+	if consensus, ok := m["ConsensusAddressOwner"].(map[string]any); ok {
+		v, err := toU64String(consensus["start_version"])
+		return v, err == nil
+	}
+	return "", false
+}
+
+func setGasData(ctx context.Context, transactionData *TransactionData, client CoreClient) error {
 	if transactionData.GasData.Price == "" {
 		var price string
-		if err := client.Call(context.Background(), "suix_getReferenceGasPrice", []any{}, &price); err != nil {
-			transactionData.GasData.Price = "1"
-		} else {
-			transactionData.GasData.Price = price
+		if err := client.Call(ctx, "suix_getReferenceGasPrice", []any{}, &price); err != nil {
+			return err
 		}
+		transactionData.GasData.Price = price
 	}
 	if transactionData.GasData.Budget == "" {
-		transactionData.GasData.Budget = itoa64(MaxGas)
+		if err := setGasBudget(ctx, transactionData, client); err != nil {
+			return err
+		}
 	}
 	if transactionData.GasData.Payment == nil {
-		transactionData.GasData.Payment = []ObjectRef{}
+		if err := setGasPayment(ctx, transactionData, client); err != nil {
+			return err
+		}
 	}
 	if transactionData.Expiration == nil {
-		transactionData.Expiration = map[string]any{
-			"$kind": "ValidDuring",
-			"ValidDuring": map[string]any{
-				"minEpoch": "0",
-				"maxEpoch": "1",
-				"chain":    "unknown",
-				"nonce":    0,
-			},
+		transactionData.Expiration = map[string]any{"$kind": "None", "None": true}
+	}
+	return nil
+}
+
+// setGasBudget dry-runs the transaction with the maximum budget and no gas
+// payment, then budgets the net gas used plus GasSafeOverhead units at the gas
+// price. The budget never drops below the computation cost plus overhead.
+func setGasBudget(ctx context.Context, transactionData *TransactionData, client CoreClient) error {
+	if transactionData.Sender == "" {
+		return errors.New("missing transaction sender")
+	}
+	dryRunData := *transactionData
+	dryRunData.GasData.Budget = itoa64(MaxGas)
+	dryRunData.GasData.Payment = []ObjectRef{}
+	value, err := transactionDataToBCS(&dryRunData)
+	if err != nil {
+		return err
+	}
+	serialized, err := BCS.TransactionData.Serialize(value, nil)
+	if err != nil {
+		return err
+	}
+	txB64, err := serialized.ToBase64()
+	if err != nil {
+		return err
+	}
+	var result map[string]any
+	if err := client.Call(ctx, "sui_dryRunTransactionBlock", []any{txB64}, &result); err != nil {
+		return err
+	}
+	effects, _ := result["effects"].(map[string]any)
+	status, _ := effects["status"].(map[string]any)
+	if status["status"] != "success" {
+		return fmt.Errorf("dry run failed, could not automatically determine a budget: %v", status["error"])
+	}
+	gasUsed, _ := effects["gasUsed"].(map[string]any)
+	cost := func(field string) (*big.Int, error) {
+		s, err := toU64String(gasUsed[field])
+		if err != nil {
+			return nil, fmt.Errorf("invalid dry run %s: %w", field, err)
 		}
+		n, _ := new(big.Int).SetString(s, 10)
+		return n, nil
+	}
+	computation, err := cost("computationCost")
+	if err != nil {
+		return err
+	}
+	storage, err := cost("storageCost")
+	if err != nil {
+		return err
+	}
+	rebate, err := cost("storageRebate")
+	if err != nil {
+		return err
+	}
+	price, ok := new(big.Int).SetString(transactionData.GasData.Price, 10)
+	if !ok {
+		return fmt.Errorf("invalid gas price: %q", transactionData.GasData.Price)
+	}
+	base := new(big.Int).Add(computation, new(big.Int).Mul(big.NewInt(GasSafeOverhead), price))
+	budget := new(big.Int).Sub(new(big.Int).Add(base, storage), rebate)
+	if budget.Cmp(base) < 0 {
+		budget = base
+	}
+	transactionData.GasData.Budget = budget.String()
+	return nil
+}
+
+// setGasPayment pages through the gas owner's SUI coins, skipping coins the
+// transaction already uses as inputs, until their balance covers the budget.
+func setGasPayment(ctx context.Context, transactionData *TransactionData, client CoreClient) error {
+	owner := transactionData.GasData.Owner
+	if owner == "" {
+		owner = transactionData.Sender
 	}
+	if owner == "" {
+		return errors.New("missing gas owner or transaction sender")
+	}
+	budget, ok := new(big.Int).SetString(transactionData.GasData.Budget, 10)
+	if !ok {
+		return fmt.Errorf("invalid gas budget: %q", transactionData.GasData.Budget)
+	}
+	payment, err := selectGasPayment(ctx, client, owner, budget, ownedInputIDs(transactionData))
+	if err != nil {
+		return err
+	}
+	transactionData.GasData.Payment = payment
 	return nil
 }
+
+// ownedInputIDs returns the IDs of the owned objects the transaction uses as
+// inputs, which cannot also pay for gas.
+func ownedInputIDs(transactionData *TransactionData) map[string]bool {
+	used := map[string]bool{}
+	for _, input := range transactionData.Inputs {
+		if input.Object != nil && input.Object.ImmOrOwnedObject != nil {
+			used[utils.NormalizeSuiObjectID(input.Object.ImmOrOwnedObject.ObjectID)] = true
+		}
+	}
+	return used
+}
+
+// selectGasPayment selects owner's SUI coins, skipping the IDs in exclude,
+// until their balance covers budget.
+func selectGasPayment(ctx context.Context, client CoreClient, owner string, budget *big.Int, exclude map[string]bool) ([]ObjectRef, error) {
+	payment, total, err := selectCoins(ctx, client, owner, suiCoinType, budget, exclude, maxGasPaymentObjects)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(payment) == 0:
+		return nil, errors.New("no valid gas coins found for the transaction")
+	case total.Cmp(budget) < 0 && len(payment) == maxGasPaymentObjects:
+		return nil, fmt.Errorf("gas budget %s needs more than %d gas coins; merge SUI coins first", budget, maxGasPaymentObjects)
+	case total.Cmp(budget) < 0:
+		return nil, fmt.Errorf("insufficient SUI balance for gas budget %s: %s available", budget, total)
+	}
+	return payment, nil
+}
+
+// selectCoins pages through owner's coins of coinType, skipping the IDs in
+// exclude, until their balance reaches target or limit coins are selected.
+// A limit of zero selects without bound. It returns the selected coins and
+// their total balance, which is below target when the owner has too little.
+func selectCoins(ctx context.Context, client CoreClient, owner, coinType string, target *big.Int, exclude map[string]bool, limit int) ([]ObjectRef, *big.Int, error) {
+	selected := []ObjectRef{}
+	total := new(big.Int)
+	full := func() bool { return limit > 0 && len(selected) >= limit }
+	var cursor any
+	for total.Cmp(target) < 0 && !full() {
+		var page map[string]any
+		if err := client.Call(ctx, "suix_getCoins", []any{owner, coinType, cursor, nil}, &page); err != nil {
+			return nil, nil, err
+		}
+		coins, _ := page["data"].([]any)
+		for _, item := range coins {
+			coin, _ := item.(map[string]any)
+			id := utils.NormalizeSuiObjectID(toString(coin["coinObjectId"]))
+			if exclude[id] {
+				continue
+			}
+			version, err := toU64String(coin["version"])
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid version for coin %s: %w", id, err)
+			}
+			balance, ok := new(big.Int).SetString(toString(coin["balance"]), 10)
+			if !ok {
+				return nil, nil, fmt.Errorf("invalid balance for coin %s: %v", id, coin["balance"])
+			}
+			selected = append(selected, ObjectRef{ObjectID: id, Version: version, Digest: toString(coin["digest"])})
+			total.Add(total, balance)
+			if total.Cmp(target) >= 0 || full() {
+				break
+			}
+		}
+		hasNext, _ := page["hasNextPage"].(bool)
+		if !hasNext || page["nextCursor"] == nil {
+			break
+		}
+		cursor = page["nextCursor"]
+	}
+	return selected, total, nil
+}
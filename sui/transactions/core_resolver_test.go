@@ -0,0 +1,375 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sui-sdks/go-sdks/bcs"
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+var testDigest = bcs.ToBase58(make([]byte, 32))
+
+// resolverClient serves sui_multiGetObjects, sui_getNormalizedMoveFunction,
+// suix_getCoins and sui_dryRunTransactionBlock from in-memory fixtures and
+// records every call it receives. Coins listed in coins by type take
+// precedence over the paged coinPages.
+type resolverClient struct {
+	objects   map[string]map[string]any
+	functions map[string]map[string]any
+	coinPages [][]any
+	coins     map[string][]any
+	gasUsed   map[string]any
+	priceErr  error
+	calls     []string
+	fetched   [][]string
+}
+
+func (c *resolverClient) Call(ctx context.Context, method string, params []any, out any) error {
+	c.calls = append(c.calls, method)
+	switch method {
+	case "sui_multiGetObjects":
+		ids := params[0].([]string)
+		c.fetched = append(c.fetched, ids)
+		res := make([]map[string]any, len(ids))
+		for i, id := range ids {
+			if obj, ok := c.objects[id]; ok {
+				res[i] = map[string]any{"data": obj}
+			} else {
+				res[i] = map[string]any{"error": map[string]any{"code": "notExists", "object_id": id}}
+			}
+		}
+		*out.(*[]map[string]any) = res
+	case "sui_getNormalizedMoveFunction":
+		target := fmt.Sprintf("%v::%v::%v", params[0], params[1], params[2])
+		fn, ok := c.functions[target]
+		if !ok {
+			return fmt.Errorf("function %s not found", target)
+		}
+		*out.(*map[string]any) = fn
+	case "suix_getReferenceGasPrice":
+		if c.priceErr != nil {
+			return c.priceErr
+		}
+		*out.(*string) = "1000"
+	case "suix_getCoins":
+		if coins, ok := c.coins[params[1].(string)]; ok {
+			*out.(*map[string]any) = map[string]any{"data": coins, "hasNextPage": false}
+			return nil
+		}
+		page := 0
+		if params[2] != nil {
+			page, _ = strconv.Atoi(params[2].(string))
+		}
+		res := map[string]any{"data": []any{}, "hasNextPage": false}
+		if page < len(c.coinPages) {
+			res["data"] = c.coinPages[page]
+		}
+		if page+1 < len(c.coinPages) {
+			res["hasNextPage"] = true
+			res["nextCursor"] = strconv.Itoa(page + 1)
+		}
+		*out.(*map[string]any) = res
+	case "sui_dryRunTransactionBlock":
+		if c.gasUsed == nil {
+			*out.(*map[string]any) = map[string]any{"effects": map[string]any{"status": map[string]any{"status": "failure", "error": "MoveAbort"}}}
+			return nil
+		}
+		*out.(*map[string]any) = map[string]any{"effects": map[string]any{"status": map[string]any{"status": "success"}, "gasUsed": c.gasUsed}}
+	}
+	return nil
+}
+
+func coin(id, balance string) map[string]any {
+	return map[string]any{"coinObjectId": id, "version": "1", "digest": testDigest, "balance": balance}
+}
+
+// recipient returns a pure address input to transfer objects to.
+func recipient(t *testing.T, tx *Transaction) Argument {
+	t.Helper()
+	arg, err := tx.Pure().Address("0x2")
+	if err != nil {
+		t.Fatalf("encode recipient failed: %v", err)
+	}
+	return arg
+}
+
+func ownedObject(id, version string) map[string]any {
+	return map[string]any{"objectId": id, "version": version, "digest": testDigest, "owner": map[string]any{"AddressOwner": "0x1"}}
+}
+
+func sharedObject(id string, initialSharedVersion float64) map[string]any {
+	return map[string]any{"objectId": id, "version": "99", "digest": testDigest, "owner": map[string]any{"Shared": map[string]any{"initial_shared_version": initialSharedVersion}}}
+}
+
+func moveStructType(address, module, name string) map[string]any {
+	return map[string]any{"Struct": map[string]any{"address": address, "module": module, "name": name, "typeArguments": []any{}}}
+}
+
+func TestResolveObjectReferences(t *testing.T) {
+	id := utils.NormalizeSuiObjectID
+	pkg := id("0x42")
+	client := &resolverClient{
+		objects: map[string]map[string]any{
+			id("0xa"): sharedObject(id("0xa"), 5),
+			id("0xb"): sharedObject(id("0xb"), 6),
+			id("0xc"): ownedObject(id("0xc"), "7"),
+			id("0xd"): ownedObject(id("0xd"), "8"),
+			id("0xe"): ownedObject(id("0xe"), "9"),
+			id("0xf"): {"objectId": id("0xf"), "version": "3", "digest": testDigest, "owner": "Immutable"},
+		},
+		functions: map[string]map[string]any{
+			pkg + "::m::f": {"parameters": []any{
+				map[string]any{"Reference": moveStructType("0x42", "m", "Pool")},
+				map[string]any{"MutableReference": moveStructType("0x42", "m", "Pool")},
+				moveStructType("0x2", "transfer", "Receiving"),
+				moveStructType("0x42", "m", "Item"),
+				map[string]any{"Reference": moveStructType("0x42", "m", "Config")},
+				map[string]any{"MutableReference": moveStructType("0x2", "tx_context", "TxContext")},
+			}},
+		},
+	}
+
+	tx := NewTransaction()
+	readOnly := tx.Object("0xa")
+	writable := tx.Object("0xb")
+	receiving := tx.Object("0xc")
+	owned := tx.Object("0xd")
+	immutable := tx.Object("0xf")
+	tx.MoveCall("0x42::m::f", []Argument{readOnly, writable, receiving, owned, immutable}, nil)
+	tx.TransferObjects([]Argument{tx.Object("0xe")}, recipient(t, tx))
+	tx.MoveCall("0x42::m::f", []Argument{tx.Object("0xa")}, nil)
+	version, digest := "1", testDigest
+	tx.Object(CallArg{UnresolvedObject: &UnresolvedObject{ObjectID: id("0x10"), Version: &version, Digest: &digest}})
+
+	if err := CoreClientResolveTransaction(&tx.data, BuildTransactionOptions{Client: client, OnlyTransactionKind: true}); err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+
+	inputs := tx.GetData().Inputs
+	if s := inputs[0].Object.SharedObject; s == nil || s.InitialSharedVersion != "5" || s.Mutable {
+		t.Fatalf("expected immutable shared object, got %+v", inputs[0].Object)
+	}
+	if s := inputs[1].Object.SharedObject; s == nil || s.InitialSharedVersion != "6" || !s.Mutable {
+		t.Fatalf("expected mutable shared object, got %+v", inputs[1].Object)
+	}
+	if r := inputs[2].Object.Receiving; r == nil || r.Version != "7" || r.Digest != testDigest {
+		t.Fatalf("expected receiving object, got %+v", inputs[2].Object)
+	}
+	if r := inputs[3].Object.ImmOrOwnedObject; r == nil || r.Version != "8" {
+		t.Fatalf("expected owned object, got %+v", inputs[3].Object)
+	}
+	if r := inputs[4].Object.ImmOrOwnedObject; r == nil || r.Version != "3" {
+		t.Fatalf("expected immutable object, got %+v", inputs[4].Object)
+	}
+	if r := inputs[5].Object.ImmOrOwnedObject; r == nil || r.Version != "9" {
+		t.Fatalf("expected transferred owned object, got %+v", inputs[5].Object)
+	}
+	if s := inputs[7].Object.SharedObject; s == nil || s.Mutable {
+		t.Fatalf("expected second read-only use of 0xa to stay immutable, got %+v", inputs[7].Object)
+	}
+	if r := inputs[8].Object.ImmOrOwnedObject; r == nil || r.Version != "1" {
+		t.Fatalf("expected caller-provided reference, got %+v", inputs[8].Object)
+	}
+
+	if len(client.fetched) != 1 || len(client.fetched[0]) != 6 {
+		t.Fatalf("expected one deduplicated batch of 6 objects, got %v", client.fetched)
+	}
+	fnCalls := 0
+	for _, c := range client.calls {
+		if c == "sui_getNormalizedMoveFunction" {
+			fnCalls++
+		}
+	}
+	if fnCalls != 1 {
+		t.Fatalf("expected move function to be fetched once, got %d", fnCalls)
+	}
+}
+
+func TestResolveObjectReferencesBatchesAndReportsMissing(t *testing.T) {
+	client := &resolverClient{objects: map[string]map[string]any{}}
+	tx := NewTransaction()
+	for i := 1; i <= maxObjectsPerFetch+1; i++ {
+		objectID := utils.NormalizeSuiObjectID(fmt.Sprintf("0x%x", i))
+		client.objects[objectID] = ownedObject(objectID, "1")
+		tx.Object(objectID)
+	}
+	if err := CoreClientResolveTransaction(&tx.data, BuildTransactionOptions{Client: client, OnlyTransactionKind: true}); err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if len(client.fetched) != 2 || len(client.fetched[0]) != maxObjectsPerFetch || len(client.fetched[1]) != 1 {
+		t.Fatalf("unexpected fetch batches: %d", len(client.fetched))
+	}
+
+	missing := NewTransaction()
+	missing.Object("0x999")
+	if err := CoreClientResolveTransaction(&missing.data, BuildTransactionOptions{Client: client, OnlyTransactionKind: true}); err == nil {
+		t.Fatalf("expected error for missing object")
+	}
+}
+
+func TestResolveUnresolvedPureFromMoveSignature(t *testing.T) {
+	pkg := utils.NormalizeSuiObjectID("0x42")
+	client := &resolverClient{functions: map[string]map[string]any{
+		pkg + "::m::g": {"parameters": []any{
+			"U64",
+			"Address",
+			map[string]any{"Vector": "U8"},
+			map[string]any{"Struct": map[string]any{"address": "0x1", "module": "option", "name": "Option", "typeArguments": []any{moveStructType("0x1", "string", "String")}}},
+			map[string]any{"TypeParameter": float64(0)},
+			map[string]any{"Reference": moveStructType("0x2", "object", "ID")},
+			map[string]any{"MutableReference": moveStructType("0x2", "tx_context", "TxContext")},
+		}},
+		pkg + "::m::h": {"parameters": []any{moveStructType("0x42", "m", "Item")}},
+	}}
+
+	tx := NewTransaction()
+	pure := func(v any) Argument { return tx.AddInput(CallArg{UnresolvedPure: &UnresolvedPure{Value: v}}) }
+	tx.MoveCall("0x42::m::g", []Argument{pure(7), pure("0x2"), pure([]any{1, 2}), pure("hi"), pure(3), pure("0x6")}, []string{"u16"})
+	tx.TransferObjects([]Argument{tx.Gas()}, pure("0x3"))
+
+	// Round-trip through JSON so numbers arrive as float64, as they would from a wallet.
+	serialized, err := tx.Serialize()
+	if err != nil {
+		t.Fatalf("serialize failed: %v", err)
+	}
+	restored, err := TransactionFrom(serialized)
+	if err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if err := ResolveTransactionPlugin(&restored.data, BuildTransactionOptions{Client: client, OnlyTransactionKind: true}, func() error { return nil }); err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+
+	sui := func(last string) string { return strings.Repeat("0", 62) + last }
+	want := []string{
+		"0700000000000000",
+		sui("02"),
+		"020102",
+		"01026869",
+		"0300",
+		sui("06"),
+		sui("03"),
+	}
+	for i, w := range want {
+		if got := pureInputHex(t, restored, InputArgument(uint16(i))); got != w {
+			t.Fatalf("input %d: got %s want %s", i, got, w)
+		}
+	}
+
+	bad := NewTransaction()
+	bad.MoveCall("0x42::m::h", []Argument{bad.AddInput(CallArg{UnresolvedPure: &UnresolvedPure{Value: 1}})}, nil)
+	if err := CoreClientResolveTransaction(&bad.data, BuildTransactionOptions{Client: client, OnlyTransactionKind: true}); err == nil {
+		t.Fatalf("expected error for pure value passed to a struct parameter")
+	}
+}
+
+func TestSetGasDataEstimatesBudgetWithDryRun(t *testing.T) {
+	client := &resolverClient{
+		coinPages: [][]any{{coin("0x100", "10000000")}},
+		gasUsed:   map[string]any{"computationCost": "1000000", "storageCost": "5000000", "storageRebate": "2000000"},
+	}
+	tx := NewTransaction()
+	tx.SetSender("0x1")
+	tx.TransferObjects([]Argument{tx.Gas()}, recipient(t, tx))
+	if err := CoreClientResolveTransaction(&tx.data, BuildTransactionOptions{Client: client}); err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	gas := tx.GetData().GasData
+	// computation + 1000 * price + storage - rebate
+	if gas.Price != "1000" || gas.Budget != "5000000" {
+		t.Fatalf("unexpected gas data: price=%s budget=%s", gas.Price, gas.Budget)
+	}
+	if len(gas.Payment) != 1 || gas.Payment[0].ObjectID != utils.NormalizeSuiObjectID("0x100") {
+		t.Fatalf("unexpected payment: %+v", gas.Payment)
+	}
+
+	// A large rebate never pushes the budget below computation plus overhead.
+	client.gasUsed = map[string]any{"computationCost": "1000000", "storageCost": "0", "storageRebate": "9000000"}
+	refund := NewTransaction()
+	refund.SetSender("0x1")
+	if err := CoreClientResolveTransaction(&refund.data, BuildTransactionOptions{Client: client}); err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if got := refund.GetData().GasData.Budget; got != "2000000" {
+		t.Fatalf("expected minimum budget 2000000, got %s", got)
+	}
+
+	client.gasUsed = nil
+	failed := NewTransaction()
+	failed.SetSender("0x1")
+	if err := CoreClientResolveTransaction(&failed.data, BuildTransactionOptions{Client: client}); err == nil || !strings.Contains(err.Error(), "dry run failed") {
+		t.Fatalf("expected dry run failure, got %v", err)
+	}
+}
+
+func TestSetGasDataReturnsGasPriceError(t *testing.T) {
+	rpcErr := errors.New("reference gas price unavailable")
+	client := &resolverClient{coinPages: [][]any{{coin("0x100", "10000000")}}, priceErr: rpcErr}
+	tx := NewTransaction()
+	tx.SetSender("0x1")
+	tx.SetGasBudget(1000)
+	if err := CoreClientResolveTransaction(&tx.data, BuildTransactionOptions{Client: client}); !errors.Is(err, rpcErr) {
+		t.Fatalf("expected the gas price error, got %v", err)
+	}
+	if got := tx.GetData().GasData.Price; got != "" {
+		t.Fatalf("expected no gas price to be set, got %s", got)
+	}
+}
+
+func TestSetGasPaymentSelectsCoins(t *testing.T) {
+	owned := utils.NormalizeSuiObjectID("0x101")
+	client := &resolverClient{
+		objects: map[string]map[string]any{owned: ownedObject(owned, "4")},
+		coinPages: [][]any{
+			{coin("0x101", "900"), coin("0x102", "300")},
+			{coin("0x103", "300"), coin("0x104", "300"), coin("0x105", "300")},
+		},
+	}
+	tx := NewTransaction()
+	tx.SetSender("0x1")
+	tx.SetGasBudget(800)
+	tx.TransferObjects([]Argument{tx.Object("0x101")}, recipient(t, tx))
+	if err := CoreClientResolveTransaction(&tx.data, BuildTransactionOptions{Client: client}); err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	var ids []string
+	for _, ref := range tx.GetData().GasData.Payment {
+		ids = append(ids, ref.ObjectID)
+	}
+	want := []string{utils.NormalizeSuiObjectID("0x102"), utils.NormalizeSuiObjectID("0x103"), utils.NormalizeSuiObjectID("0x104")}
+	if strings.Join(ids, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected payment: got %v want %v", ids, want)
+	}
+
+	poor := NewTransaction()
+	poor.SetSender("0x1")
+	poor.SetGasBudget(5000)
+	if err := CoreClientResolveTransaction(&poor.data, BuildTransactionOptions{Client: client}); err == nil || !strings.Contains(err.Error(), "insufficient SUI balance") {
+		t.Fatalf("expected insufficient balance error, got %v", err)
+	}
+
+	dust := make([]any, maxGasPaymentObjects+1)
+	for i := range dust {
+		dust[i] = coin(fmt.Sprintf("0x%x", 0x1000+i), "1")
+	}
+	client.coinPages = [][]any{dust}
+	many := NewTransaction()
+	many.SetSender("0x1")
+	many.SetGasBudget(1000)
+	if err := CoreClientResolveTransaction(&many.data, BuildTransactionOptions{Client: client}); err == nil || !strings.Contains(err.Error(), "merge SUI coins") {
+		t.Fatalf("expected too many coins error, got %v", err)
+	}
+
+	client.coinPages = nil
+	empty := NewTransaction()
+	empty.SetSender("0x1")
+	empty.SetGasBudget(1)
+	if err := CoreClientResolveTransaction(&empty.data, BuildTransactionOptions{Client: client}); err == nil || !strings.Contains(err.Error(), "no valid gas coins") {
+		t.Fatalf("expected no coins error, got %v", err)
+	}
+}
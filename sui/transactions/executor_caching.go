@@ -4,8 +4,12 @@ import (
 	"context"
 	"encoding/base64"
 	"errors"
+	"fmt"
+	"math/big"
+	"sync"
 
 	"github.com/sui-sdks/go-sdks/sui/cryptography"
+	"github.com/sui-sdks/go-sdks/sui/utils"
 )
 
 type ExecuteTransactionOptions struct {
@@ -21,6 +25,7 @@ type ExecuteCore interface {
 type CachingTransactionExecutor struct {
 	client     ExecuteCore
 	cache      *ObjectCache
+	mu         sync.Mutex
 	lastDigest string
 }
 
@@ -32,64 +37,191 @@ func NewCachingTransactionExecutor(client ExecuteCore, cache *ObjectCache) *Cach
 }
 
 func (e *CachingTransactionExecutor) Reset() error {
+	return e.reset(context.Background())
+}
+
+func (e *CachingTransactionExecutor) reset(ctx context.Context) error {
 	e.cache.ClearOwnedObjects()
 	e.cache.ClearCustom()
-	return e.WaitForLastTransaction()
+	return e.WaitForLastTransactionContext(ctx)
 }
 
 func (e *CachingTransactionExecutor) BuildTransaction(tx *Transaction, options BuildTransactionOptions) ([]byte, error) {
-	data := tx.GetData()
-	if err := ResolveTransactionPlugin(&data, options, func() error { return nil }); err != nil {
-		return nil, err
+	return e.BuildTransactionContext(context.Background(), tx, options)
+}
+
+func (e *CachingTransactionExecutor) BuildTransactionContext(ctx context.Context, tx *Transaction, options BuildTransactionOptions) ([]byte, error) {
+	options.ctx = ctx
+	if options.Limits == nil && options.Client != nil {
+		limits, err := e.protocolLimits(ctx, options.Client)
+		if err != nil {
+			return nil, err
+		}
+		options.Limits = &limits
+	}
+	return tx.build(options, []TransactionPlugin{e.cache.AsPlugin()})
+}
+
+const protocolLimitsCacheKey = "protocolLimits"
+
+// protocolLimits fetches the protocol limits once and keeps them in the cache
+// until it is reset, falling back to DefaultProtocolLimits without caching
+// them when the client cannot report them.
+func (e *CachingTransactionExecutor) protocolLimits(ctx context.Context, client CoreClient) (ProtocolLimits, error) {
+	if limits, ok := e.cache.GetCustom(protocolLimitsCacheKey); ok {
+		return limits.(ProtocolLimits), nil
+	}
+	limits, err := FetchProtocolLimits(ctx, client)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ProtocolLimits{}, ctx.Err()
+		}
+		return DefaultProtocolLimits, nil
 	}
-	tx.data = data
-	return tx.Build()
+	e.cache.SetCustom(protocolLimitsCacheKey, limits)
+	return limits, nil
 }
 
 func (e *CachingTransactionExecutor) ExecuteTransaction(opts ExecuteTransactionOptions) (map[string]any, error) {
+	return e.ExecuteTransactionContext(context.Background(), opts)
+}
+
+func (e *CachingTransactionExecutor) ExecuteTransactionContext(ctx context.Context, opts ExecuteTransactionOptions) (map[string]any, error) {
 	if len(opts.Signatures) == 0 {
 		return nil, errors.New("at least one signature is required")
 	}
 	txB64 := base64.StdEncoding.EncodeToString(opts.Transaction)
+	// Effects are always requested so the object cache can be updated.
+	include := map[string]any{}
+	for k, v := range opts.Include {
+		include[k] = v
+	}
+	include["showEffects"] = true
 	var out map[string]any
-	err := e.client.Call(context.Background(), "sui_executeTransactionBlock", []any{txB64, opts.Signatures, opts.Include, "WaitForLocalExecution"}, &out)
+	err := e.client.Call(ctx, "sui_executeTransactionBlock", []any{txB64, opts.Signatures, include, "WaitForLocalExecution"}, &out)
 	if err != nil {
 		return nil, err
 	}
 	if digest, ok := out["digest"].(string); ok {
+		e.mu.Lock()
 		e.lastDigest = digest
+		e.mu.Unlock()
+	}
+	if effects, ok := out["effects"].(map[string]any); ok {
+		e.ApplyEffects(effects)
 	}
 	return out, nil
 }
 
+// ExecuteSignedTransaction executes a transaction that already carries every
+// required signature, such as a sponsored transaction signed by both parties.
+func (e *CachingTransactionExecutor) ExecuteSignedTransaction(signed *SignedTransaction, include map[string]any) (map[string]any, error) {
+	return e.ExecuteSignedTransactionContext(context.Background(), signed, include)
+}
+
+func (e *CachingTransactionExecutor) ExecuteSignedTransactionContext(ctx context.Context, signed *SignedTransaction, include map[string]any) (map[string]any, error) {
+	return e.ExecuteTransactionContext(ctx, ExecuteTransactionOptions{Transaction: signed.Bytes, Signatures: signed.Signatures, Include: include})
+}
+
 func (e *CachingTransactionExecutor) SignAndExecuteTransaction(tx *Transaction, signer interface {
 	ToSuiAddress() string
 	SignTransaction([]byte) (cryptography.SignatureWithBytes, error)
+}, include map[string]any) (map[string]any, error) {
+	return e.SignAndExecuteTransactionContext(context.Background(), tx, signer, include)
+}
+
+func (e *CachingTransactionExecutor) SignAndExecuteTransactionContext(ctx context.Context, tx *Transaction, signer interface {
+	ToSuiAddress() string
+	SignTransaction([]byte) (cryptography.SignatureWithBytes, error)
 }, include map[string]any) (map[string]any, error) {
 	tx.SetSenderIfNotSet(signer.ToSuiAddress())
-	bytes, err := e.BuildTransaction(tx, BuildTransactionOptions{Client: e.client})
+	bytes, err := e.BuildTransactionContext(ctx, tx, BuildTransactionOptions{Client: e.client})
 	if err != nil {
 		return nil, err
 	}
-	sig, err := signer.SignTransaction(bytes)
+	sig, err := signTransaction(ctx, signer, bytes)
 	if err != nil {
 		return nil, err
 	}
-	return e.ExecuteTransaction(ExecuteTransactionOptions{Transaction: bytes, Signatures: []string{sig.Signature}, Include: include})
+	return e.ExecuteTransactionContext(ctx, ExecuteTransactionOptions{Transaction: bytes, Signatures: []string{sig}, Include: include})
 }
 
 func (e *CachingTransactionExecutor) WaitForLastTransaction() error {
-	if e.lastDigest == "" {
+	return e.WaitForLastTransactionContext(context.Background())
+}
+
+// WaitForLastTransactionContext waits until the fullnode has indexed the last
+// transaction this executor executed.
+func (e *CachingTransactionExecutor) WaitForLastTransactionContext(ctx context.Context) error {
+	e.mu.Lock()
+	digest := e.lastDigest
+	e.mu.Unlock()
+	if digest == "" {
 		return nil
 	}
 	var out map[string]any
-	err := e.client.Call(context.Background(), "sui_getTransactionBlock", []any{e.lastDigest, map[string]any{}}, &out)
+	err := e.client.Call(ctx, "sui_getTransactionBlock", []any{digest, map[string]any{}}, &out)
 	if err == nil {
-		e.lastDigest = ""
+		e.mu.Lock()
+		if e.lastDigest == digest {
+			e.lastDigest = ""
+		}
+		e.mu.Unlock()
 	}
 	return err
 }
 
+// signTransaction signs bytes unless ctx is already done; signers take no
+// context of their own.
+func signTransaction(ctx context.Context, signer interface {
+	SignTransaction([]byte) (cryptography.SignatureWithBytes, error)
+}, bytes []byte) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	sig, err := signer.SignTransaction(bytes)
+	if err != nil {
+		return "", err
+	}
+	return sig.Signature, nil
+}
+
 func (e *CachingTransactionExecutor) ApplyEffects(effects map[string]any) {
-	_ = effects
+	e.cache.ApplyEffects(effects)
+}
+
+// gasObjectFromEffects returns the gas coin reference and its address owner
+// from JSON-RPC transaction effects.
+func gasObjectFromEffects(effects map[string]any) (ObjectRef, string, bool) {
+	gas, _ := effects["gasObject"].(map[string]any)
+	owner, _ := gas["owner"].(map[string]any)
+	ref, _ := gas["reference"].(map[string]any)
+	if ref == nil {
+		return ObjectRef{}, "", false
+	}
+	version, err := toU64String(ref["version"])
+	if err != nil {
+		return ObjectRef{}, "", false
+	}
+	return ObjectRef{ObjectID: utils.NormalizeSuiObjectID(toString(ref["objectId"])), Version: version, Digest: toString(ref["digest"])}, toString(owner["AddressOwner"]), true
+}
+
+// gasCostFromEffects returns the net gas charged by a transaction: the
+// computation and storage costs less the storage rebate.
+func gasCostFromEffects(effects map[string]any) (*big.Int, error) {
+	gasUsed, _ := effects["gasUsed"].(map[string]any)
+	total := new(big.Int)
+	for _, field := range []string{"computationCost", "storageCost", "storageRebate"} {
+		s, err := toU64String(gasUsed[field])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s in effects: %w", field, err)
+		}
+		n, _ := new(big.Int).SetString(s, 10)
+		if field == "storageRebate" {
+			total.Sub(total, n)
+		} else {
+			total.Add(total, n)
+		}
+	}
+	return total, nil
 }
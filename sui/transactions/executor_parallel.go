@@ -1,9 +1,15 @@
 package transactions
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
 	"sync"
 
 	"github.com/sui-sdks/go-sdks/sui/cryptography"
+	"github.com/sui-sdks/go-sdks/sui/utils"
 )
 
 type ParallelTransactionExecutorOptions struct {
@@ -15,6 +21,18 @@ type ParallelTransactionExecutorOptions struct {
 	DefaultGasBudget int64
 	MaxPoolSize      int
 	Cache            *ObjectCache
+	// CoinBatchSize is the most gas coins split off in one pool refill.
+	CoinBatchSize int
+	// InitialCoinBalance is the balance of each new pool coin.
+	InitialCoinBalance uint64
+	// MinimumCoinBalance is the balance below which a used coin is merged
+	// back into the source coins instead of returning to the pool.
+	MinimumCoinBalance uint64
+	// SourceCoins are the coins pool refills split from. When empty, the
+	// first refill pays with any of the signer's SUI coins.
+	SourceCoins []string
+	// Retry, when set, retries failed executions that are safe to retry.
+	Retry *RetryPolicy
 }
 
 type ParallelTransactionExecutor struct {
@@ -26,8 +44,25 @@ type ParallelTransactionExecutor struct {
 	buildQueue       SerialQueue
 	execQueue        *ParallelQueue
 	defaultGasBudget int64
-	objectQueues     map[string][]func()
+	objectQueues     map[string][]*objectLock
 	objectMu         sync.Mutex
+	retry            *RetryPolicy
+
+	maxPoolSize         int
+	coinBatchSize       int
+	initialCoinBalance  uint64
+	minimumCoinBalance  uint64
+	poolMu              sync.Mutex
+	coinPool            []poolCoin
+	sourceCoins         map[string]*ObjectRef
+	pendingTransactions int
+}
+
+// poolCoin is a gas coin owned by the signer and reserved for the parallel
+// executor, with the balance it is known to hold.
+type poolCoin struct {
+	ref     ObjectRef
+	balance *big.Int
 }
 
 func NewParallelTransactionExecutor(opts ParallelTransactionExecutorOptions) *ParallelTransactionExecutor {
@@ -39,12 +74,37 @@ func NewParallelTransactionExecutor(opts ParallelTransactionExecutorOptions) *Pa
 	if maxPool <= 0 {
 		maxPool = 50
 	}
+	batchSize := opts.CoinBatchSize
+	if batchSize <= 0 {
+		batchSize = 20
+	}
+	initialBalance := opts.InitialCoinBalance
+	if initialBalance == 0 {
+		initialBalance = 200_000_000
+	}
+	minimumBalance := opts.MinimumCoinBalance
+	if minimumBalance == 0 {
+		minimumBalance = 50_000_000
+	}
+	var sourceCoins map[string]*ObjectRef
+	if len(opts.SourceCoins) > 0 {
+		sourceCoins = map[string]*ObjectRef{}
+		for _, id := range opts.SourceCoins {
+			sourceCoins[utils.NormalizeSuiObjectID(id)] = nil
+		}
+	}
 	return &ParallelTransactionExecutor{
-		signer:           opts.Signer,
-		cacheExec:        NewCachingTransactionExecutor(opts.Client, opts.Cache),
-		execQueue:        NewParallelQueue(maxPool),
-		defaultGasBudget: budget,
-		objectQueues:     map[string][]func(){},
+		signer:             opts.Signer,
+		cacheExec:          NewCachingTransactionExecutor(opts.Client, opts.Cache),
+		execQueue:          NewParallelQueue(maxPool),
+		defaultGasBudget:   budget,
+		objectQueues:       map[string][]*objectLock{},
+		maxPoolSize:        maxPool,
+		coinBatchSize:      batchSize,
+		initialCoinBalance: initialBalance,
+		minimumCoinBalance: minimumBalance,
+		sourceCoins:        sourceCoins,
+		retry:              opts.Retry,
 	}
 }
 
@@ -56,76 +116,542 @@ func (e *ParallelTransactionExecutor) WaitForLastTransaction() error {
 	return e.cacheExec.WaitForLastTransaction()
 }
 
+func (e *ParallelTransactionExecutor) WaitForLastTransactionContext(ctx context.Context) error {
+	return e.cacheExec.WaitForLastTransactionContext(ctx)
+}
+
 func (e *ParallelTransactionExecutor) ExecuteTransaction(tx *Transaction, include map[string]any, additionalSignatures []string) (map[string]any, error) {
-	usedObjects := getUsedObjects(tx)
-	for _, objectID := range usedObjects {
-		e.lockObject(objectID)
-		defer e.unlockObject(objectID)
+	return e.ExecuteTransactionContext(context.Background(), tx, include, additionalSignatures)
+}
+
+// ExecuteTransactionContext executes tx once no earlier transaction is using
+// any of its owned objects. ctx bounds waiting for those objects and for a
+// free slot, as well as building, signing and executing. With a retry
+// policy, failed attempts are retried after the backoff, with the objects
+// released in between.
+func (e *ParallelTransactionExecutor) ExecuteTransactionContext(ctx context.Context, tx *Transaction, include map[string]any, additionalSignatures []string) (map[string]any, error) {
+	usedObjects, err := e.getUsedObjects(ctx, tx)
+	if err != nil {
+		return nil, err
 	}
+	r := &retrier{policy: e.retry}
+	budget := e.defaultGasBudget
+	for {
+		lock, err := e.lockObjects(ctx, usedObjects)
+		if err != nil {
+			return nil, err
+		}
+		out, built, unlocked, err := e.execute(ctx, r, tx, include, additionalSignatures, usedObjects, budget)
+		e.unlockObjects(lock)
+		if len(unlocked) > 0 {
+			// Resolution added owned objects, such as coins picked by an
+			// intent resolver, that were not locked. Wait for those too and
+			// rebuild.
+			usedObjects = append(usedObjects, unlocked...)
+			continue
+		}
+		if err == nil && executionFailure(out) == nil {
+			r.finish(ctx, built, out, nil)
+			return out, nil
+		}
+		next, retry := r.rebuild(ctx, tx, built, out, err, budget)
+		if !retry {
+			return out, err
+		}
+		budget = next
+	}
+}
 
+// execute builds, signs and executes tx while its objects are locked. When the
+// built transaction uses owned objects outside locked it is not executed, and
+// those objects are returned instead.
+func (e *ParallelTransactionExecutor) execute(ctx context.Context, r *retrier, tx *Transaction, include map[string]any, additionalSignatures []string, locked []string, budget int64) (map[string]any, []byte, []string, error) {
 	var out map[string]any
-	err := e.execQueue.RunTask(func() error {
-		var built []byte
-		if err := e.buildQueue.RunTask(func() error {
-			copyTx := *tx
+	var built []byte
+	var unlocked []string
+	err := e.execQueue.RunTaskContext(ctx, func() error {
+		var resolved *Transaction
+		var gasCoin *poolCoin
+		copyTx := tx.Clone()
+		defer func() {
+			if gasCoin != nil {
+				e.poolMu.Lock()
+				e.pendingTransactions--
+				e.poolMu.Unlock()
+			}
+		}()
+		if err := e.buildQueue.RunTaskContext(ctx, func() error {
 			copyTx.SetSenderIfNotSet(e.signer.ToSuiAddress())
-			copyTx.SetGasBudgetIfNotSet(e.defaultGasBudget)
-			b, err := e.cacheExec.BuildTransaction(&copyTx, BuildTransactionOptions{Client: e.cacheExec.client})
+			copyTx.SetGasBudgetIfNotSet(budget)
+			coin, err := e.getGasCoin(ctx)
+			if err != nil {
+				return err
+			}
+			gasCoin = &coin
+			e.poolMu.Lock()
+			e.pendingTransactions++
+			e.poolMu.Unlock()
+			copyTx.SetGasPayment([]ObjectRef{coin.ref})
+			b, err := e.cacheExec.BuildTransactionContext(ctx, copyTx, BuildTransactionOptions{Client: e.cacheExec.client})
 			if err != nil {
 				return err
 			}
 			built = b
-			return nil
+			resolved, err = TransactionFromBytes(b)
+			return err
 		}); err != nil {
+			e.discardGasCoin(gasCoin)
 			return err
 		}
-		sig, err := e.signer.SignTransaction(built)
+		isLocked := map[string]bool{}
+		for _, id := range locked {
+			isLocked[id] = true
+		}
+		for _, id := range ownedInputObjects(&resolved.data) {
+			if !isLocked[id] {
+				unlocked = append(unlocked, id)
+			}
+		}
+		if len(unlocked) > 0 {
+			e.restoreGasCoin(*gasCoin)
+			return nil
+		}
+		sig, err := signTransaction(ctx, e.signer, built)
 		if err != nil {
+			e.restoreGasCoin(*gasCoin)
 			return err
 		}
-		sigs := append([]string{sig.Signature}, additionalSignatures...)
-		res, err := e.cacheExec.ExecuteTransaction(ExecuteTransactionOptions{Transaction: built, Signatures: sigs, Include: include})
+		sigs := append([]string{sig}, additionalSignatures...)
+		res, err := e.cacheExec.submit(ctx, r, ExecuteTransactionOptions{Transaction: built, Signatures: sigs, Include: include})
 		if err != nil {
-			_ = e.cacheExec.Reset()
+			e.discardGasCoin(gasCoin)
+			_ = e.cacheExec.reset(ctx)
 			return err
 		}
+		e.returnGasCoin(*gasCoin, resolved, res)
 		out = res
 		return nil
 	})
+	return out, built, unlocked, err
+}
+
+// ExecuteSignedTransaction executes a transaction signed by another party,
+// such as a sponsor, adding the executor's signature when it is required.
+func (e *ParallelTransactionExecutor) ExecuteSignedTransaction(signed *SignedTransaction, include map[string]any) (map[string]any, error) {
+	return e.ExecuteSignedTransactionContext(context.Background(), signed, include)
+}
+
+func (e *ParallelTransactionExecutor) ExecuteSignedTransactionContext(ctx context.Context, signed *SignedTransaction, include map[string]any) (map[string]any, error) {
+	tx, err := signed.Transaction()
+	if err != nil {
+		return nil, err
+	}
+	lock, err := e.lockObjects(ctx, ownedInputObjects(&tx.data))
+	if err != nil {
+		return nil, err
+	}
+	defer e.unlockObjects(lock)
+
+	// Signed bytes cannot be rebuilt, so only timeouts are retried.
+	r := &retrier{policy: e.retry}
+	var out map[string]any
+	err = e.execQueue.RunTaskContext(ctx, func() error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		sigs, err := executorSignatures(signed, e.signer)
+		if err != nil {
+			return err
+		}
+		res, err := e.cacheExec.submit(ctx, r, ExecuteTransactionOptions{Transaction: signed.Bytes, Signatures: sigs, Include: include})
+		if err != nil {
+			_ = e.cacheExec.reset(ctx)
+			return err
+		}
+		out = res
+		return nil
+	})
+	if err == nil && executionFailure(out) == nil {
+		r.finish(ctx, signed.Bytes, out, nil)
+	} else {
+		r.rebuild(ctx, nil, signed.Bytes, out, err, 0)
+	}
 	return out, err
 }
 
-func getUsedObjects(tx *Transaction) []string {
-	seen := map[string]struct{}{}
+// ownedInputObjects returns the owned and receiving objects among the
+// resolved inputs of a transaction.
+func ownedInputObjects(data *TransactionData) []string {
+	seen := map[string]bool{}
 	out := []string{}
+	for _, input := range data.Inputs {
+		if input.Object == nil {
+			continue
+		}
+		var ref *ObjectRef
+		switch {
+		case input.Object.ImmOrOwnedObject != nil:
+			ref = input.Object.ImmOrOwnedObject
+		case input.Object.Receiving != nil:
+			ref = input.Object.Receiving
+		default:
+			continue
+		}
+		id := utils.NormalizeSuiObjectID(ref.ObjectID)
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// getUsedObjects returns the objects tx must lock. Unresolved object inputs
+// are looked up in the cache, or fetched, to tell owned objects from shared
+// ones, which are not locked.
+func (e *ParallelTransactionExecutor) getUsedObjects(ctx context.Context, tx *Transaction) ([]string, error) {
+	out := ownedInputObjects(&tx.data)
+	seen := map[string]bool{}
+	for _, id := range out {
+		seen[id] = true
+	}
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	fetch := []string{}
 	for _, input := range tx.data.Inputs {
-		if obj, ok := input["Object"].(map[string]any); ok {
-			if imm, ok := obj["ImmOrOwnedObject"].(map[string]any); ok {
-				if id, ok := imm["objectId"].(string); ok {
-					if _, exists := seen[id]; !exists {
-						seen[id] = struct{}{}
-						out = append(out, id)
-					}
-				}
+		obj := input.UnresolvedObject
+		if obj == nil || obj.InitialSharedVersion != nil {
+			continue
+		}
+		id := utils.NormalizeSuiObjectID(obj.ObjectID)
+		if cached, ok := e.cacheExec.cache.GetObject(id); ok {
+			if _, shared := initialSharedVersionFromOwner(cached["owner"]); !shared {
+				add(id)
 			}
+			continue
 		}
+		if obj.Version != nil && obj.Digest != nil {
+			add(id)
+			continue
+		}
+		fetch = append(fetch, id)
 	}
-	return out
+	if len(fetch) > 0 {
+		objects, err := fetchObjects(ctx, e.cacheExec.client, fetch)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range fetch {
+			if objects[id].initialSharedVersion == nil {
+				add(id)
+			}
+		}
+	}
+	return out, nil
+}
+
+// objectLock is a transaction queued on the objects it uses. It holds them
+// once it is first in the queue of every one.
+type objectLock struct {
+	ids     []string
+	waiting int
+	ready   chan struct{}
 }
 
-func (e *ParallelTransactionExecutor) lockObject(id string) {
+// lockObjects queues on every object in ids at once, so transactions acquire
+// shared objects in the order they arrived, and blocks until all are held or
+// ctx is done.
+func (e *ParallelTransactionExecutor) lockObjects(ctx context.Context, ids []string) (*objectLock, error) {
+	lock := &objectLock{ids: ids, ready: make(chan struct{})}
 	e.objectMu.Lock()
-	defer e.objectMu.Unlock()
-	e.objectQueues[id] = append(e.objectQueues[id], func() {})
+	for _, id := range ids {
+		queue := e.objectQueues[id]
+		if len(queue) > 0 {
+			lock.waiting++
+		}
+		e.objectQueues[id] = append(queue, lock)
+	}
+	if lock.waiting == 0 {
+		close(lock.ready)
+	}
+	e.objectMu.Unlock()
+
+	select {
+	case <-lock.ready:
+		return lock, nil
+	case <-ctx.Done():
+		e.unlockObjects(lock)
+		return nil, ctx.Err()
+	}
 }
 
-func (e *ParallelTransactionExecutor) unlockObject(id string) {
+// unlockObjects removes lock from its object queues, waking the transactions
+// that were waiting only on it.
+func (e *ParallelTransactionExecutor) unlockObjects(lock *objectLock) {
 	e.objectMu.Lock()
 	defer e.objectMu.Unlock()
-	q := e.objectQueues[id]
-	if len(q) <= 1 {
-		delete(e.objectQueues, id)
+	for _, id := range lock.ids {
+		queue := e.objectQueues[id]
+		for i, l := range queue {
+			if l != lock {
+				continue
+			}
+			queue = append(queue[:i:i], queue[i+1:]...)
+			if i == 0 && len(queue) > 0 {
+				next := queue[0]
+				next.waiting--
+				if next.waiting == 0 {
+					close(next.ready)
+				}
+			}
+			break
+		}
+		if len(queue) == 0 {
+			delete(e.objectQueues, id)
+		} else {
+			e.objectQueues[id] = queue
+		}
+	}
+}
+
+// getGasCoin takes a coin from the pool, refilling the pool first when it is
+// empty. It runs on the build queue, so only one refill happens at a time.
+func (e *ParallelTransactionExecutor) getGasCoin(ctx context.Context) (poolCoin, error) {
+	if coin, ok := e.popGasCoin(); ok {
+		return coin, nil
+	}
+	if err := e.refillCoinPool(ctx); err != nil {
+		return poolCoin{}, err
+	}
+	if coin, ok := e.popGasCoin(); ok {
+		return coin, nil
+	}
+	return poolCoin{}, errors.New("refilling the gas coin pool produced no coins")
+}
+
+func (e *ParallelTransactionExecutor) popGasCoin() (poolCoin, bool) {
+	e.poolMu.Lock()
+	defer e.poolMu.Unlock()
+	if len(e.coinPool) == 0 {
+		return poolCoin{}, false
+	}
+	coin := e.coinPool[0]
+	e.coinPool = e.coinPool[1:]
+	return coin, true
+}
+
+// returnGasCoin puts a used coin back in the pool at its new version. Coins
+// that fall below the minimum balance, or whose balance can no longer be
+// tracked because the transaction used the gas coin, are merged into the
+// next refill instead.
+func (e *ParallelTransactionExecutor) returnGasCoin(coin poolCoin, tx *Transaction, res map[string]any) {
+	effects, _ := res["effects"].(map[string]any)
+	ref, owner, ok := gasObjectFromEffects(effects)
+	if !ok {
+		e.discardGasCoin(&coin)
 		return
 	}
-	e.objectQueues[id] = q[1:]
+	if utils.NormalizeSuiAddress(owner) != utils.NormalizeSuiAddress(e.signer.ToSuiAddress()) {
+		return
+	}
+	e.poolMu.Lock()
+	defer e.poolMu.Unlock()
+	cost, err := gasCostFromEffects(effects)
+	if err == nil && !usesGasCoin(tx) {
+		remaining := new(big.Int).Sub(coin.balance, cost)
+		if remaining.Cmp(new(big.Int).SetUint64(e.minimumCoinBalance)) >= 0 {
+			e.coinPool = append(e.coinPool, poolCoin{ref: ref, balance: remaining})
+			return
+		}
+	}
+	if e.sourceCoins == nil {
+		e.sourceCoins = map[string]*ObjectRef{}
+	}
+	e.sourceCoins[ref.ObjectID] = &ref
+}
+
+// restoreGasCoin puts back a coin that was taken from the pool but not used.
+func (e *ParallelTransactionExecutor) restoreGasCoin(coin poolCoin) {
+	e.poolMu.Lock()
+	defer e.poolMu.Unlock()
+	e.coinPool = append([]poolCoin{coin}, e.coinPool...)
+}
+
+// discardGasCoin hands a coin whose state is unknown, for example after a
+// failed execution, to the next refill, which reloads it.
+func (e *ParallelTransactionExecutor) discardGasCoin(coin *poolCoin) {
+	if coin == nil {
+		return
+	}
+	e.poolMu.Lock()
+	defer e.poolMu.Unlock()
+	if e.sourceCoins == nil {
+		e.sourceCoins = map[string]*ObjectRef{}
+	}
+	e.sourceCoins[coin.ref.ObjectID] = nil
+}
+
+func usesGasCoin(tx *Transaction) bool {
+	used := false
+	for _, cmd := range tx.data.Commands {
+		mapCommandArguments(cmd, func(arg Argument) Argument {
+			if arg.Kind == ArgumentGasCoin {
+				used = true
+			}
+			return arg
+		})
+	}
+	return used
+}
+
+// refillCoinPool splits new pool coins off the source coins, merging every
+// source coin into the gas coin. The first refill without source coins pays
+// with enough of the signer's SUI coins to cover the new pool coins and the
+// gas budget; its gas coin then becomes the source of later refills, so
+// refills never spend coins that are already in the pool.
+func (e *ParallelTransactionExecutor) refillCoinPool(ctx context.Context) error {
+	e.poolMu.Lock()
+	batchSize := min(e.coinBatchSize, e.maxPoolSize-(len(e.coinPool)+e.pendingTransactions)+1)
+	var sources map[string]*ObjectRef
+	if e.sourceCoins != nil {
+		sources = make(map[string]*ObjectRef, len(e.sourceCoins))
+		for id, ref := range e.sourceCoins {
+			sources[id] = ref
+		}
+	}
+	e.poolMu.Unlock()
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	address := e.signer.ToSuiAddress()
+	tx := NewTransaction()
+	tx.SetSender(address)
+	if sources != nil {
+		refs, err := e.loadSourceCoins(ctx, sources)
+		if err != nil {
+			return err
+		}
+		if len(refs) == 0 {
+			return errors.New("no source coins available to refill the gas coin pool")
+		}
+		tx.SetGasPayment(refs)
+	} else {
+		// The resolver would only select coins covering the gas budget.
+		tx.SetGasBudget(e.defaultGasBudget)
+		target := new(big.Int).Mul(new(big.Int).SetUint64(e.initialCoinBalance), big.NewInt(int64(batchSize)))
+		target.Add(target, big.NewInt(e.defaultGasBudget))
+		payment, err := selectGasPayment(ctx, e.cacheExec.client, address, target, map[string]bool{})
+		if err != nil {
+			return fmt.Errorf("refill the gas coin pool: %w", err)
+		}
+		tx.SetGasPayment(payment)
+	}
+	amounts := make([]Argument, batchSize)
+	for i := range amounts {
+		amounts[i] = tx.Pure().U64(e.initialCoinBalance)
+	}
+	split := tx.SplitCoins(tx.Gas(), amounts)
+	coins := make([]Argument, batchSize)
+	for i := range coins {
+		coins[i] = NestedResultArgument(split.Index, uint16(i))
+	}
+	recipient, err := tx.Pure().Address(address)
+	if err != nil {
+		return err
+	}
+	tx.TransferObjects(coins, recipient)
+
+	if err := e.cacheExec.WaitForLastTransactionContext(ctx); err != nil {
+		return err
+	}
+	built, err := e.cacheExec.BuildTransactionContext(ctx, tx, BuildTransactionOptions{Client: e.cacheExec.client})
+	if err != nil {
+		return err
+	}
+	sig, err := signTransaction(ctx, e.signer, built)
+	if err != nil {
+		return err
+	}
+	res, err := e.cacheExec.ExecuteTransactionContext(ctx, ExecuteTransactionOptions{Transaction: built, Signatures: []string{sig}})
+	if err != nil {
+		// The refill may still have run, so reload the source coins next time.
+		e.poolMu.Lock()
+		for id := range sources {
+			if _, ok := e.sourceCoins[id]; ok {
+				e.sourceCoins[id] = nil
+			}
+		}
+		e.poolMu.Unlock()
+		return err
+	}
+
+	effects, _ := res["effects"].(map[string]any)
+	created, _ := effects["created"].([]any)
+	e.poolMu.Lock()
+	defer e.poolMu.Unlock()
+	for _, item := range created {
+		entry, _ := item.(map[string]any)
+		owner, _ := entry["owner"].(map[string]any)
+		if utils.NormalizeSuiAddress(toString(owner["AddressOwner"])) != utils.NormalizeSuiAddress(address) {
+			continue
+		}
+		ref, _ := entry["reference"].(map[string]any)
+		version, err := toU64String(ref["version"])
+		if ref == nil || err != nil {
+			continue
+		}
+		e.coinPool = append(e.coinPool, poolCoin{
+			ref:     ObjectRef{ObjectID: utils.NormalizeSuiObjectID(toString(ref["objectId"])), Version: version, Digest: toString(ref["digest"])},
+			balance: new(big.Int).SetUint64(e.initialCoinBalance),
+		})
+	}
+	if e.sourceCoins == nil {
+		e.sourceCoins = map[string]*ObjectRef{}
+	}
+	for id := range sources {
+		delete(e.sourceCoins, id)
+	}
+	if gas, _, ok := gasObjectFromEffects(effects); ok {
+		e.sourceCoins[gas.ObjectID] = &gas
+	}
+	return nil
+}
+
+// loadSourceCoins returns references for the source coins, fetching the ones
+// whose current version is unknown. Coins that no longer exist are skipped.
+func (e *ParallelTransactionExecutor) loadSourceCoins(ctx context.Context, sources map[string]*ObjectRef) ([]ObjectRef, error) {
+	refs := []ObjectRef{}
+	missing := []string{}
+	for id, ref := range sources {
+		if ref != nil {
+			refs = append(refs, *ref)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	for start := 0; start < len(missing); start += maxObjectsPerFetch {
+		chunk := missing[start:min(start+maxObjectsPerFetch, len(missing))]
+		var objects []map[string]any
+		if err := e.cacheExec.client.Call(ctx, "sui_multiGetObjects", []any{chunk, map[string]any{}}, &objects); err != nil {
+			return nil, err
+		}
+		for i, obj := range objects {
+			data, _ := obj["data"].(map[string]any)
+			if data == nil || i >= len(chunk) {
+				continue
+			}
+			version, err := toU64String(data["version"])
+			if err != nil {
+				continue
+			}
+			refs = append(refs, ObjectRef{ObjectID: chunk[i], Version: version, Digest: toString(data["digest"])})
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].ObjectID < refs[j].ObjectID })
+	return refs, nil
 }
@@ -0,0 +1,267 @@
+package transactions
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	edkp "github.com/sui-sdks/go-sdks/sui/keypairs/ed25519"
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+// lockingCore is a fake fullnode that reports owned and shared objects and
+// tracks how many executing transactions use each owned object at once.
+type lockingCore struct {
+	mockCore
+	shared map[string]bool
+	// onExecute, when set, runs for every executed transaction before it
+	// completes.
+	onExecute func(data TransactionData)
+
+	mu       sync.Mutex
+	active   map[string]int
+	overlaps []string
+	order    []string
+}
+
+func (c *lockingCore) Call(ctx context.Context, method string, params []any, out any) error {
+	switch method {
+	case "sui_multiGetObjects":
+		ids := params[0].([]string)
+		objects := make([]map[string]any, len(ids))
+		for i, id := range ids {
+			owner := any(map[string]any{"AddressOwner": "0x1"})
+			if c.shared[id] {
+				owner = map[string]any{"Shared": map[string]any{"initial_shared_version": "1"}}
+			}
+			objects[i] = map[string]any{"data": map[string]any{"objectId": id, "version": "1", "digest": testDigest, "owner": owner}}
+		}
+		*out.(*[]map[string]any) = objects
+		return nil
+	case "sui_executeTransactionBlock":
+		raw, _ := base64.StdEncoding.DecodeString(params[0].(string))
+		tx, err := TransactionFromBytes(raw)
+		if err != nil {
+			return err
+		}
+		data := tx.GetData()
+		owned := ownedInputObjects(&data)
+		c.mu.Lock()
+		for _, id := range owned {
+			c.active[id]++
+			if c.active[id] > 1 {
+				c.overlaps = append(c.overlaps, id)
+			}
+		}
+		c.mu.Unlock()
+		if c.onExecute != nil {
+			c.onExecute(data)
+		} else {
+			time.Sleep(time.Millisecond)
+		}
+		c.mu.Lock()
+		for _, id := range owned {
+			c.active[id]--
+			c.order = append(c.order, id)
+		}
+		c.mu.Unlock()
+	}
+	return c.mockCore.Call(ctx, method, params, out)
+}
+
+func newLockingExecutor(t *testing.T, client *lockingCore) *ParallelTransactionExecutor {
+	t.Helper()
+	signer, err := edkp.Generate()
+	if err != nil {
+		t.Fatalf("generate signer failed: %v", err)
+	}
+	client.active = map[string]int{}
+	return NewParallelTransactionExecutor(ParallelTransactionExecutorOptions{Client: client, Signer: signer, CoinBatchSize: 10, MaxPoolSize: 10})
+}
+
+func useObjects(ids ...string) *Transaction {
+	tx := NewTransaction()
+	args := make([]Argument, len(ids))
+	for i, id := range ids {
+		args[i] = tx.Object(id)
+	}
+	tx.MoveCall("0x2::test::touch", args, nil)
+	return tx
+}
+
+func TestParallelExecutorSerializesOwnedObjects(t *testing.T) {
+	client := &lockingCore{}
+	exec := newLockingExecutor(t, client)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 12)
+	for i := 0; i < 12; i++ {
+		// Objects are passed unresolved, so ownership is only known after
+		// they are looked up.
+		ids := []string{"0xa"}
+		if i%2 == 0 {
+			ids = append(ids, "0xb")
+		}
+		wg.Add(1)
+		go func(tx *Transaction) {
+			defer wg.Done()
+			_, err := exec.ExecuteTransaction(tx, nil, nil)
+			errs <- err
+		}(useObjects(ids...))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("execute failed: %v", err)
+		}
+	}
+	if len(client.overlaps) > 0 {
+		t.Fatalf("transactions using the same owned objects ran concurrently: %v", client.overlaps)
+	}
+	if len(exec.objectQueues) != 0 {
+		t.Fatalf("expected all object queues to be released, got %v", exec.objectQueues)
+	}
+}
+
+func TestParallelExecutorRunsIndependentTransactionsConcurrently(t *testing.T) {
+	// Both transactions share a shared object, which is not locked, so the
+	// first can only finish once the second has started executing.
+	started := make(chan struct{})
+	var once sync.Once
+	client := &lockingCore{shared: map[string]bool{utils.NormalizeSuiObjectID("0x5"): true}}
+	client.onExecute = func(data TransactionData) {
+		if len(data.Commands) == 0 || data.Commands[0].MoveCall == nil {
+			return
+		}
+		if ownedInputObjects(&data)[0] == utils.NormalizeSuiObjectID("0xc") {
+			select {
+			case <-started:
+			case <-time.After(2 * time.Second):
+			}
+			return
+		}
+		once.Do(func() { close(started) })
+	}
+	exec := newLockingExecutor(t, client)
+
+	first := make(chan error, 1)
+	go func() {
+		_, err := exec.ExecuteTransaction(useObjects("0xc", "0x5"), nil, nil)
+		first <- err
+	}()
+	if _, err := exec.ExecuteTransaction(useObjects("0xd", "0x5"), nil, nil); err != nil {
+		t.Fatalf("second execute failed: %v", err)
+	}
+	select {
+	case <-started:
+	default:
+		t.Fatalf("second transaction did not execute")
+	}
+	if err := <-first; err != nil {
+		t.Fatalf("first execute failed: %v", err)
+	}
+}
+
+func TestObjectLocksAreFIFO(t *testing.T) {
+	exec := newLockingExecutor(t, &lockingCore{})
+	holder, err := exec.lockObjects(context.Background(), []string{"0xa"})
+	if err != nil {
+		t.Fatalf("lock failed: %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []int
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids := []string{"0xa"}
+			if i%2 == 1 {
+				ids = append(ids, "0xb")
+			}
+			lock, err := exec.lockObjects(context.Background(), ids)
+			if err != nil {
+				t.Errorf("lock %d failed: %v", i, err)
+				return
+			}
+			mu.Lock()
+			order = append(order, i)
+			mu.Unlock()
+			exec.unlockObjects(lock)
+		}(i)
+		waitForQueue(t, exec, "0xa", i+2)
+	}
+	exec.unlockObjects(holder)
+	wg.Wait()
+	for i, got := range order {
+		if got != i {
+			t.Fatalf("expected locks in arrival order, got %v", order)
+		}
+	}
+}
+
+func TestObjectLockHonorsContext(t *testing.T) {
+	client := &lockingCore{}
+	exec := newLockingExecutor(t, client)
+	id := utils.NormalizeSuiObjectID("0xa")
+	holder, err := exec.lockObjects(context.Background(), []string{id})
+	if err != nil {
+		t.Fatalf("lock failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := exec.ExecuteTransactionContext(ctx, useObjects("0xa"), nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error while waiting for the object, got %v", err)
+	}
+	if got := len(exec.objectQueues[id]); got != 1 {
+		t.Fatalf("cancelled transaction must leave the queue, got %d entries", got)
+	}
+
+	exec.unlockObjects(holder)
+	if _, err := exec.ExecuteTransaction(useObjects("0xa"), nil, nil); err != nil {
+		t.Fatalf("execute after release failed: %v", err)
+	}
+}
+
+func waitForQueue(t *testing.T, exec *ParallelTransactionExecutor, id string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		exec.objectMu.Lock()
+		got := len(exec.objectQueues[id])
+		exec.objectMu.Unlock()
+		if got >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d entries in the queue of %s", n, id)
+}
+
+func TestParallelExecutorRefillPaysWithSmallCoins(t *testing.T) {
+	signer, _ := edkp.Generate()
+	// Two pool coins of 0.2 SUI and the 0.05 SUI budget need all five coins.
+	client := &coinsCore{coins: mockCoins(5, "100000000")}
+	exec := NewParallelTransactionExecutor(ParallelTransactionExecutorOptions{Client: client, Signer: signer, CoinBatchSize: 2, MaxPoolSize: 2})
+	tx := NewTransaction()
+	tx.MoveCall("0x2::example::run", nil, nil)
+	if _, err := exec.ExecuteTransaction(tx, nil, nil); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	refill := client.executed[0]
+	if len(refill.GasData.Payment) != 5 || refill.GasData.Budget != "50000000" {
+		t.Fatalf("expected the refill to pay with all five coins, got %+v", refill.GasData)
+	}
+
+	client = &coinsCore{coins: mockCoins(4, "100000000")}
+	exec = NewParallelTransactionExecutor(ParallelTransactionExecutorOptions{Client: client, Signer: signer, CoinBatchSize: 2, MaxPoolSize: 2})
+	if _, err := exec.ExecuteTransaction(tx, nil, nil); err == nil || len(client.executed) != 0 {
+		t.Fatalf("expected a refill that cannot cover its coins to fail before executing, got %v", err)
+	}
+}
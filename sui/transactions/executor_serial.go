@@ -1,9 +1,27 @@
 package transactions
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/sui-sdks/go-sdks/sui/cryptography"
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+// Gas modes of the serial executor.
+const (
+	// GasModeCoins pays gas with the signer's coins, reusing the gas coin
+	// returned by the previous transaction.
+	GasModeCoins = "coins"
+	// GasModeAddressBalance pays gas from the signer's address balance. The
+	// transaction has no gas payment and expires after the next epoch.
+	GasModeAddressBalance = "addressBalance"
 )
 
 type SerialTransactionExecutorOptions struct {
@@ -15,6 +33,11 @@ type SerialTransactionExecutorOptions struct {
 	DefaultGasBudget int64
 	GasMode          string
 	Cache            *ObjectCache
+	// Retry, when set, retries failed executions that are safe to retry.
+	Retry *RetryPolicy
+	// SponsorLease is how long the gas coins of a sponsored transaction stay
+	// reserved for it. Defaults to one minute.
+	SponsorLease time.Duration
 }
 
 type SerialTransactionExecutor struct {
@@ -26,6 +49,14 @@ type SerialTransactionExecutor struct {
 	cacheExec        *CachingTransactionExecutor
 	defaultGasBudget int64
 	gasMode          string
+	retry            *RetryPolicy
+	sponsorLease     time.Duration
+	// leases maps the gas coins of sponsored transactions to the end of
+	// their lease. It is only used from tasks on the queue.
+	leases map[string]time.Time
+
+	chainMu sync.Mutex
+	chain   string
 }
 
 func NewSerialTransactionExecutor(opts SerialTransactionExecutorOptions) *SerialTransactionExecutor {
@@ -35,13 +66,20 @@ func NewSerialTransactionExecutor(opts SerialTransactionExecutorOptions) *Serial
 	}
 	mode := opts.GasMode
 	if mode == "" {
-		mode = "coins"
+		mode = GasModeCoins
+	}
+	lease := opts.SponsorLease
+	if lease <= 0 {
+		lease = time.Minute
 	}
 	return &SerialTransactionExecutor{
 		signer:           opts.Signer,
 		cacheExec:        NewCachingTransactionExecutor(opts.Client, opts.Cache),
 		defaultGasBudget: budget,
 		gasMode:          mode,
+		retry:            opts.Retry,
+		sponsorLease:     lease,
+		leases:           map[string]time.Time{},
 	}
 }
 
@@ -50,12 +88,15 @@ func (e *SerialTransactionExecutor) ApplyEffects(effects map[string]any) {
 }
 
 func (e *SerialTransactionExecutor) BuildTransaction(tx *Transaction) ([]byte, error) {
+	return e.BuildTransactionContext(context.Background(), tx)
+}
+
+// BuildTransactionContext builds tx on the executor's queue. The build is
+// dropped if ctx is done before its turn comes.
+func (e *SerialTransactionExecutor) BuildTransactionContext(ctx context.Context, tx *Transaction) ([]byte, error) {
 	var out []byte
-	err := e.queue.RunTask(func() error {
-		copyTx := *tx
-		copyTx.SetGasBudgetIfNotSet(e.defaultGasBudget)
-		copyTx.SetSenderIfNotSet(e.signer.ToSuiAddress())
-		built, err := e.cacheExec.BuildTransaction(&copyTx, BuildTransactionOptions{Client: e.cacheExec.client, OnlyTransactionKind: false})
+	err := e.queue.RunTaskContext(ctx, func() error {
+		built, err := e.buildTransaction(ctx, tx, e.defaultGasBudget)
 		if err != nil {
 			return err
 		}
@@ -66,43 +107,289 @@ func (e *SerialTransactionExecutor) BuildTransaction(tx *Transaction) ([]byte, e
 }
 
 func (e *SerialTransactionExecutor) ExecuteTransaction(txOrBytes any, include map[string]any, additionalSignatures []string) (map[string]any, error) {
+	return e.ExecuteTransactionContext(context.Background(), txOrBytes, include, additionalSignatures)
+}
+
+// ExecuteTransactionContext builds, signs and executes on the executor's
+// queue. The transaction is dropped if ctx is done before its turn comes.
+// With a retry policy, failed attempts are retried on the queue after the
+// backoff.
+func (e *SerialTransactionExecutor) ExecuteTransactionContext(ctx context.Context, txOrBytes any, include map[string]any, additionalSignatures []string) (map[string]any, error) {
 	if len(additionalSignatures) == 0 {
 		additionalSignatures = []string{}
 	}
+	r := &retrier{policy: e.retry}
+	tx, _ := txOrBytes.(*Transaction)
+	budget := e.defaultGasBudget
+	for {
+		res, bytes, err := e.executeOnce(ctx, r, txOrBytes, include, additionalSignatures, budget)
+		if err == nil && executionFailure(res) == nil {
+			r.finish(ctx, bytes, res, nil)
+			return res, nil
+		}
+		next, retry := r.rebuild(ctx, tx, bytes, res, err, budget)
+		if !retry {
+			return res, err
+		}
+		budget = next
+	}
+}
+
+func (e *SerialTransactionExecutor) executeOnce(ctx context.Context, r *retrier, txOrBytes any, include map[string]any, additionalSignatures []string, budget int64) (map[string]any, []byte, error) {
 	var out map[string]any
-	err := e.queue.RunTask(func() error {
-		var bytes []byte
+	var bytes []byte
+	err := e.queue.RunTaskContext(ctx, func() error {
+		var sigs []string
 		switch v := txOrBytes.(type) {
 		case *Transaction:
-			copyTx := *v
-			copyTx.SetGasBudgetIfNotSet(e.defaultGasBudget)
-			copyTx.SetSenderIfNotSet(e.signer.ToSuiAddress())
-			b, err := e.cacheExec.BuildTransaction(&copyTx, BuildTransactionOptions{Client: e.cacheExec.client, OnlyTransactionKind: false})
+			b, err := e.buildTransaction(ctx, v, budget)
 			if err != nil {
 				return err
 			}
 			bytes = b
 		case []byte:
 			bytes = v
+		case *SignedTransaction:
+			// Sponsored transactions arrive with the other party's signature.
+			bytes = v.Bytes
+			signatures, err := executorSignatures(v, e.signer)
+			if err != nil {
+				return err
+			}
+			sigs = append(signatures, additionalSignatures...)
 		default:
 			return errors.New("unsupported transaction type")
 		}
-		sig, err := e.signer.SignTransaction(bytes)
+		if sigs == nil {
+			sig, err := signTransaction(ctx, e.signer, bytes)
+			if err != nil {
+				return err
+			}
+			sigs = append([]string{sig}, additionalSignatures...)
+		}
+		res, err := e.cacheExec.submit(ctx, r, ExecuteTransactionOptions{Transaction: bytes, Signatures: sigs, Include: include})
+		if err != nil {
+			_ = e.cacheExec.reset(ctx)
+			return err
+		}
+		e.cacheGasCoin(res)
+		e.releaseLeases(bytes)
+		out = res
+		return nil
+	})
+	return out, bytes, err
+}
+
+// buildTransaction builds a copy of tx paying gas according to the gas mode.
+func (e *SerialTransactionExecutor) buildTransaction(ctx context.Context, tx *Transaction, budget int64) ([]byte, error) {
+	copyTx := tx.Clone()
+	copyTx.SetGasBudgetIfNotSet(budget)
+	copyTx.SetSenderIfNotSet(e.signer.ToSuiAddress())
+	switch e.gasMode {
+	case GasModeCoins:
+		e.useCachedGasCoin(copyTx)
+		if err := e.avoidLeasedCoins(ctx, copyTx); err != nil {
+			return nil, err
+		}
+	case GasModeAddressBalance:
+		if copyTx.data.GasData.Payment == nil {
+			copyTx.SetGasPayment([]ObjectRef{})
+		}
+		if copyTx.data.Expiration == nil {
+			expiration, err := e.validDuringExpiration(ctx)
+			if err != nil {
+				return nil, err
+			}
+			copyTx.SetExpiration(expiration)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported gas mode: %q", e.gasMode)
+	}
+	return e.cacheExec.BuildTransactionContext(ctx, copyTx, BuildTransactionOptions{Client: e.cacheExec.client, OnlyTransactionKind: false})
+}
+
+// validDuringExpiration returns an expiration valid in the current and the
+// next epoch, which lets a transaction without gas coins be replay protected.
+func (e *SerialTransactionExecutor) validDuringExpiration(ctx context.Context) (map[string]any, error) {
+	chain, err := e.chainIdentifier(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var state map[string]any
+	if err := e.cacheExec.client.Call(ctx, "suix_getLatestSuiSystemState", []any{}, &state); err != nil {
+		return nil, err
+	}
+	epoch, err := toU64String(state["epoch"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid epoch in system state: %w", err)
+	}
+	current, _ := strconv.ParseUint(epoch, 10, 64)
+	return map[string]any{"$kind": "ValidDuring", "ValidDuring": map[string]any{
+		"minEpoch":     epoch,
+		"maxEpoch":     strconv.FormatUint(current+1, 10),
+		"minTimestamp": nil,
+		"maxTimestamp": nil,
+		"chain":        chain,
+		"nonce":        rand.Uint32(),
+	}}, nil
+}
+
+// chainIdentifier returns the digest of the genesis checkpoint, which
+// identifies the chain in ValidDuring expirations.
+func (e *SerialTransactionExecutor) chainIdentifier(ctx context.Context) (string, error) {
+	e.chainMu.Lock()
+	defer e.chainMu.Unlock()
+	if e.chain != "" {
+		return e.chain, nil
+	}
+	var checkpoint map[string]any
+	if err := e.cacheExec.client.Call(ctx, "sui_getCheckpoint", []any{"0"}, &checkpoint); err != nil {
+		return "", err
+	}
+	digest := toString(checkpoint["digest"])
+	if digest == "" {
+		return "", errors.New("genesis checkpoint has no digest")
+	}
+	e.chain = digest
+	return digest, nil
+}
+
+const gasCoinCacheKey = "gasCoin"
+
+// cacheGasCoin remembers the signer's gas coin from the execution effects, so
+// the next transaction can pay with it before the fullnode indexes the new
+// coin version.
+func (e *SerialTransactionExecutor) cacheGasCoin(res map[string]any) {
+	if e.gasMode != GasModeCoins {
+		return
+	}
+	effects, _ := res["effects"].(map[string]any)
+	ref, owner, ok := gasObjectFromEffects(effects)
+	if !ok || utils.NormalizeSuiAddress(owner) != utils.NormalizeSuiAddress(e.signer.ToSuiAddress()) {
+		e.cacheExec.cache.DeleteCustom(gasCoinCacheKey)
+		return
+	}
+	e.cacheExec.cache.SetCustom(gasCoinCacheKey, ref)
+}
+
+func (e *SerialTransactionExecutor) useCachedGasCoin(tx *Transaction) {
+	if tx.data.GasData.Payment != nil {
+		return
+	}
+	if owner := tx.data.GasData.Owner; owner != "" && utils.NormalizeSuiAddress(owner) != utils.NormalizeSuiAddress(e.signer.ToSuiAddress()) {
+		return
+	}
+	if coin, ok := e.cacheExec.cache.GetCustom(gasCoinCacheKey); ok {
+		tx.SetGasPayment([]ObjectRef{coin.(ObjectRef)})
+	}
+}
+
+// SponsorTransaction pays for another sender's transaction kind with the
+// executor's signer as gas owner, returning it signed by the sponsor. The
+// gas coins are leased to the returned transaction until it is executed
+// through the executor or the lease ends, so other sponsorships and the
+// executor's own transactions do not pick them in the meantime.
+func (e *SerialTransactionExecutor) SponsorTransaction(kind []byte, sender string) (*SignedTransaction, error) {
+	return e.SponsorTransactionContext(context.Background(), kind, sender)
+}
+
+func (e *SerialTransactionExecutor) SponsorTransactionContext(ctx context.Context, kind []byte, sender string) (*SignedTransaction, error) {
+	var out *SignedTransaction
+	err := e.queue.RunTaskContext(ctx, func() error {
+		tx, err := sponsoredTransaction(kind, sender, e.signer.ToSuiAddress(), SponsorTransactionOptions{GasBudget: e.defaultGasBudget})
+		if err != nil {
+			return err
+		}
+		exclude := e.leasedCoins()
+		if coin, ok := e.cacheExec.cache.GetCustom(gasCoinCacheKey); ok {
+			exclude[coin.(ObjectRef).ObjectID] = true
+		}
+		if err := e.selectGas(ctx, tx, exclude); err != nil {
+			return err
+		}
+		bytes, err := e.cacheExec.BuildTransactionContext(ctx, tx, BuildTransactionOptions{Client: e.cacheExec.client})
 		if err != nil {
 			return err
 		}
-		sigs := append([]string{sig.Signature}, additionalSignatures...)
-		res, err := e.cacheExec.ExecuteTransaction(ExecuteTransactionOptions{Transaction: bytes, Signatures: sigs, Include: include})
+		sig, err := signTransaction(ctx, e.signer, bytes)
 		if err != nil {
-			_ = e.cacheExec.Reset()
 			return err
 		}
-		out = res
+		until := time.Now().Add(e.sponsorLease)
+		for _, coin := range tx.data.GasData.Payment {
+			e.leases[coin.ObjectID] = until
+		}
+		out = NewSignedTransaction(bytes, sig)
 		return nil
 	})
 	return out, err
 }
 
+// leasedCoins drops the expired leases and returns the coins still leased to
+// sponsored transactions.
+func (e *SerialTransactionExecutor) leasedCoins() map[string]bool {
+	now := time.Now()
+	leased := make(map[string]bool, len(e.leases))
+	for id, until := range e.leases {
+		if now.After(until) {
+			delete(e.leases, id)
+			continue
+		}
+		leased[id] = true
+	}
+	return leased
+}
+
+// avoidLeasedCoins selects the gas payment of the executor's own transaction
+// when coins are leased to sponsored transactions, which the resolver would
+// not know to skip.
+func (e *SerialTransactionExecutor) avoidLeasedCoins(ctx context.Context, tx *Transaction) error {
+	if tx.data.GasData.Payment != nil {
+		return nil
+	}
+	if owner := tx.data.GasData.Owner; owner != "" && utils.NormalizeSuiAddress(owner) != utils.NormalizeSuiAddress(e.signer.ToSuiAddress()) {
+		return nil
+	}
+	leased := e.leasedCoins()
+	if len(leased) == 0 {
+		return nil
+	}
+	return e.selectGas(ctx, tx, leased)
+}
+
+// selectGas sets the gas payment of tx to the signer's coins covering its
+// budget, skipping the coins in exclude and the transaction's own inputs.
+func (e *SerialTransactionExecutor) selectGas(ctx context.Context, tx *Transaction, exclude map[string]bool) error {
+	budget, ok := new(big.Int).SetString(tx.data.GasData.Budget, 10)
+	if !ok {
+		return fmt.Errorf("invalid gas budget: %q", tx.data.GasData.Budget)
+	}
+	for id := range ownedInputIDs(&tx.data) {
+		exclude[id] = true
+	}
+	payment, err := selectGasPayment(ctx, e.cacheExec.client, e.signer.ToSuiAddress(), budget, exclude)
+	if err != nil {
+		return err
+	}
+	tx.SetGasPayment(payment)
+	return nil
+}
+
+// releaseLeases ends the leases of the gas coins an executed transaction
+// paid with.
+func (e *SerialTransactionExecutor) releaseLeases(txBytes []byte) {
+	if len(e.leases) == 0 {
+		return
+	}
+	tx, err := TransactionFromBytes(txBytes)
+	if err != nil {
+		return
+	}
+	for _, coin := range tx.data.GasData.Payment {
+		delete(e.leases, coin.ObjectID)
+	}
+}
+
 func (e *SerialTransactionExecutor) ResetCache() error {
 	return e.cacheExec.Reset()
 }
@@ -110,3 +397,7 @@ func (e *SerialTransactionExecutor) ResetCache() error {
 func (e *SerialTransactionExecutor) WaitForLastTransaction() error {
 	return e.cacheExec.WaitForLastTransaction()
 }
+
+func (e *SerialTransactionExecutor) WaitForLastTransactionContext(ctx context.Context) error {
+	return e.cacheExec.WaitForLastTransactionContext(ctx)
+}
@@ -0,0 +1,100 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	edkp "github.com/sui-sdks/go-sdks/sui/keypairs/ed25519"
+)
+
+// gasModeCore is a fake fullnode that reports the chain and epoch and counts
+// the calls each gas mode makes.
+type gasModeCore struct {
+	mockCore
+	calls map[string]int
+}
+
+func (c *gasModeCore) Call(ctx context.Context, method string, params []any, out any) error {
+	c.calls[method]++
+	switch method {
+	case "sui_getCheckpoint":
+		*out.(*map[string]any) = map[string]any{"sequenceNumber": "0", "digest": testDigest}
+		return nil
+	case "suix_getLatestSuiSystemState":
+		*out.(*map[string]any) = map[string]any{"epoch": "7"}
+		return nil
+	}
+	return c.mockCore.Call(ctx, method, params, out)
+}
+
+func buildWithGasMode(t *testing.T, mode string) (*gasModeCore, []TransactionData) {
+	t.Helper()
+	signer, _ := edkp.Generate()
+	client := &gasModeCore{calls: map[string]int{}}
+	exec := NewSerialTransactionExecutor(SerialTransactionExecutorOptions{Client: client, Signer: signer, GasMode: mode})
+	var built []TransactionData
+	for i := 0; i < 2; i++ {
+		tx := NewTransaction()
+		tx.TransferObjects([]Argument{tx.Object(Inputs.ObjectRef(ObjectRef{ObjectID: "0xa", Version: "1", Digest: testDigest}))}, recipient(t, tx))
+		if _, err := exec.ExecuteTransaction(tx, nil, nil); err != nil {
+			t.Fatalf("execute %d failed: %v", i, err)
+		}
+		bytes, err := exec.BuildTransaction(tx)
+		if err != nil {
+			t.Fatalf("build %d failed: %v", i, err)
+		}
+		resolved, err := TransactionFromBytes(bytes)
+		if err != nil {
+			t.Fatalf("parse built transaction: %v", err)
+		}
+		built = append(built, resolved.GetData())
+	}
+	return client, built
+}
+
+func TestSerialExecutorCoinGasMode(t *testing.T) {
+	client, built := buildWithGasMode(t, "")
+	for _, data := range built {
+		if len(data.GasData.Payment) != 1 {
+			t.Fatalf("expected a gas coin payment, got %+v", data.GasData.Payment)
+		}
+		if kind := fmt.Sprint(asMap(data.Expiration)["$kind"]); kind != "None" {
+			t.Fatalf("expected no expiration, got %v", data.Expiration)
+		}
+	}
+	if client.calls["sui_getCheckpoint"] != 0 || client.calls["suix_getLatestSuiSystemState"] != 0 {
+		t.Fatalf("coin gas mode must not look up the chain or epoch, got %v", client.calls)
+	}
+}
+
+func TestSerialExecutorAddressBalanceGasMode(t *testing.T) {
+	client, built := buildWithGasMode(t, GasModeAddressBalance)
+	for _, data := range built {
+		if data.GasData.Payment == nil || len(data.GasData.Payment) != 0 {
+			t.Fatalf("expected an empty gas payment, got %+v", data.GasData.Payment)
+		}
+		exp := asMap(data.Expiration)
+		vd := asMap(exp["ValidDuring"])
+		if exp["$kind"] != "ValidDuring" || fmt.Sprint(vd["minEpoch"]) != "7" || fmt.Sprint(vd["maxEpoch"]) != "8" || vd["chain"] != testDigest {
+			t.Fatalf("expected a ValidDuring expiration for epochs 7-8 on the test chain, got %v", data.Expiration)
+		}
+	}
+	if client.calls["suix_getCoins"] != 0 {
+		t.Fatalf("address balance gas mode must not select coins, got %v", client.calls)
+	}
+	if client.calls["sui_getCheckpoint"] != 1 {
+		t.Fatalf("expected the chain identifier to be fetched once, got %v", client.calls)
+	}
+}
+
+func TestSerialExecutorRejectsUnknownGasMode(t *testing.T) {
+	signer, _ := edkp.Generate()
+	exec := NewSerialTransactionExecutor(SerialTransactionExecutorOptions{Client: &gasModeCore{calls: map[string]int{}}, Signer: signer, GasMode: "sponsor"})
+	tx := NewTransaction()
+	tx.TransferObjects([]Argument{tx.Object(Inputs.ObjectRef(ObjectRef{ObjectID: "0xa", Version: "1", Digest: testDigest}))}, recipient(t, tx))
+	if _, err := exec.ExecuteTransaction(tx, nil, nil); err == nil || !strings.Contains(err.Error(), "unsupported gas mode") {
+		t.Fatalf("expected an unsupported gas mode error, got %v", err)
+	}
+}
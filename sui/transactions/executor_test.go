@@ -1,11 +1,18 @@
 package transactions
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	edkp "github.com/sui-sdks/go-sdks/sui/keypairs/ed25519"
+	"github.com/sui-sdks/go-sdks/sui/utils"
 )
 
 type mockCore struct{}
@@ -16,9 +23,25 @@ func (m mockCore) Call(ctx context.Context, method string, params []any, out any
 		if p, ok := out.(*string); ok {
 			*p = "1000"
 		}
+	case "suix_getCoins":
+		if p, ok := out.(*map[string]any); ok {
+			*p = map[string]any{"data": []any{map[string]any{
+				"coinObjectId": "0x100",
+				"version":      "1",
+				"digest":       testDigest,
+				"balance":      "100000000000",
+			}}, "hasNextPage": false}
+		}
+	case "sui_dryRunTransactionBlock":
+		if p, ok := out.(*map[string]any); ok {
+			*p = map[string]any{"effects": map[string]any{
+				"status":  map[string]any{"status": "success"},
+				"gasUsed": map[string]any{"computationCost": "1000000", "storageCost": "2000000", "storageRebate": "500000"},
+			}}
+		}
 	case "sui_executeTransactionBlock":
 		if p, ok := out.(*map[string]any); ok {
-			*p = map[string]any{"digest": "abc"}
+			*p = map[string]any{"digest": "abc", "effects": mockEffects(params[0].(string))}
 		}
 	case "sui_getTransactionBlock":
 		if p, ok := out.(*map[string]any); ok {
@@ -34,13 +57,54 @@ func (m mockCore) Call(ctx context.Context, method string, params []any, out any
 	return nil
 }
 
+var mockObjectIDs atomic.Uint64
+
+// mockEffects returns effects for an executed transaction in which every
+// SplitCoins result is created for the sender and the first gas coin is
+// charged a fixed cost.
+func mockEffects(txB64 string) map[string]any {
+	raw, _ := base64.StdEncoding.DecodeString(txB64)
+	tx, err := TransactionFromBytes(raw)
+	if err != nil {
+		return map[string]any{}
+	}
+	data := tx.GetData()
+	owner := map[string]any{"AddressOwner": data.Sender}
+	created := []any{}
+	for _, cmd := range data.Commands {
+		if cmd.SplitCoins == nil {
+			continue
+		}
+		for range cmd.SplitCoins.Amounts {
+			id := fmt.Sprintf("0x%x", 0x1000+mockObjectIDs.Add(1))
+			created = append(created, map[string]any{"owner": owner, "reference": map[string]any{"objectId": id, "version": float64(2), "digest": testDigest}})
+		}
+	}
+	effects := map[string]any{
+		"status":  map[string]any{"status": "success"},
+		"created": created,
+		"gasUsed": map[string]any{"computationCost": "1000000", "storageCost": "2000000", "storageRebate": "500000"},
+	}
+	if len(data.GasData.Payment) > 0 {
+		gas := data.GasData.Payment[0]
+		version, _ := strconv.ParseUint(gas.Version, 10, 64)
+		effects["gasObject"] = map[string]any{"owner": owner, "reference": map[string]any{"objectId": gas.ObjectID, "version": float64(version + 1), "digest": testDigest}}
+	}
+	return effects
+}
+
 func TestResolveTransactionPlugin(t *testing.T) {
 	tx := NewTransaction()
-	tx.data.Inputs = append(tx.data.Inputs, CallArg{"$kind": "UnresolvedPure", "UnresolvedPure": map[string]any{"value": 10}})
+	tx.SetSender("0x1")
+	amount := tx.AddInput(CallArg{UnresolvedPure: &UnresolvedPure{Value: 10}})
+	tx.SplitCoins(tx.Gas(), []Argument{amount})
 	err := ResolveTransactionPlugin(&tx.data, BuildTransactionOptions{Client: mockCore{}}, func() error { return nil })
 	if err != nil {
 		t.Fatalf("resolve plugin failed: %v", err)
 	}
+	if pure := tx.data.Inputs[0].Pure; pure == nil || !bytes.Equal(pure.Bytes, []byte{10, 0, 0, 0, 0, 0, 0, 0}) {
+		t.Fatalf("expected u64 amount, got %+v", tx.data.Inputs[0])
+	}
 }
 
 func TestCachingSerialParallelExecutors(t *testing.T) {
@@ -72,3 +136,195 @@ func TestCachingSerialParallelExecutors(t *testing.T) {
 		t.Fatalf("parallel execute failed: %v", err)
 	}
 }
+
+// recordingCore records every executed transaction.
+type recordingCore struct {
+	mockCore
+	mu       sync.Mutex
+	executed []TransactionData
+}
+
+func (c *recordingCore) Call(ctx context.Context, method string, params []any, out any) error {
+	if method == "sui_executeTransactionBlock" {
+		raw, _ := base64.StdEncoding.DecodeString(params[0].(string))
+		tx, err := TransactionFromBytes(raw)
+		if err != nil {
+			return err
+		}
+		c.mu.Lock()
+		c.executed = append(c.executed, tx.GetData())
+		c.mu.Unlock()
+	}
+	return c.mockCore.Call(ctx, method, params, out)
+}
+
+// coinsCore is a recordingCore whose addresses each own the given SUI coins.
+type coinsCore struct {
+	recordingCore
+	coins []any
+}
+
+func (c *coinsCore) Call(ctx context.Context, method string, params []any, out any) error {
+	if p, ok := out.(*map[string]any); ok && method == "suix_getCoins" {
+		*p = map[string]any{"data": c.coins, "hasNextPage": false}
+		return nil
+	}
+	return c.recordingCore.Call(ctx, method, params, out)
+}
+
+// mockCoins returns n coins of balance with the IDs 0x201, 0x202 and so on.
+func mockCoins(n int, balance string) []any {
+	coins := make([]any, n)
+	for i := range coins {
+		coins[i] = map[string]any{"coinObjectId": fmt.Sprintf("0x%x", 0x201+i), "version": "1", "digest": testDigest, "balance": balance}
+	}
+	return coins
+}
+
+func TestParallelExecutorGasCoinPool(t *testing.T) {
+	signer, _ := edkp.Generate()
+	client := &recordingCore{}
+	exec := NewParallelTransactionExecutor(ParallelTransactionExecutorOptions{Client: client, Signer: signer, CoinBatchSize: 3, MaxPoolSize: 3})
+	recipient := func(tx *Transaction) Argument {
+		arg, err := tx.Pure().Address(signer.ToSuiAddress())
+		if err != nil {
+			t.Fatalf("recipient failed: %v", err)
+		}
+		return arg
+	}
+	transfer := func(id string) *Transaction {
+		tx := NewTransaction()
+		tx.TransferObjects([]Argument{tx.Object(Inputs.ObjectRef(ObjectRef{ObjectID: id, Version: "1", Digest: testDigest}))}, recipient(tx))
+		return tx
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 3)
+	for _, id := range []string{"0xa1", "0xa2", "0xa3"} {
+		wg.Add(1)
+		go func(tx *Transaction) {
+			defer wg.Done()
+			_, err := exec.ExecuteTransaction(tx, nil, nil)
+			errs <- err
+		}(transfer(id))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("parallel execute failed: %v", err)
+		}
+	}
+
+	refill := client.executed[0]
+	if len(refill.Commands) != 2 || len(refill.Commands[0].SplitCoins.Amounts) != 3 {
+		t.Fatalf("expected first transaction to split 3 pool coins, got %+v", refill.Commands)
+	}
+	if len(refill.GasData.Payment) != 1 || refill.GasData.Payment[0].ObjectID != utils.NormalizeSuiObjectID("0x100") {
+		t.Fatalf("expected first refill to pay with the signer's coins, got %+v", refill.GasData.Payment)
+	}
+	used := map[string]bool{}
+	for _, data := range client.executed[1:] {
+		if len(data.GasData.Payment) != 1 {
+			t.Fatalf("expected one pool coin per transaction, got %+v", data.GasData.Payment)
+		}
+		used[data.GasData.Payment[0].ObjectID] = true
+	}
+	if len(client.executed) != 4 || len(used) != 3 {
+		t.Fatalf("expected 3 transactions on distinct pool coins after one refill, got %d transactions on %d coins", len(client.executed)-1, len(used))
+	}
+
+	// Transactions that spend the gas coin retire it; the next refill merges
+	// the retired coins with the previous refill's gas coin.
+	for i := 0; i < 3; i++ {
+		tx := NewTransaction()
+		tx.TransferObjects([]Argument{tx.SplitCoins(tx.Gas(), []Argument{tx.Pure().U64(1)})}, recipient(tx))
+		if _, err := exec.ExecuteTransaction(tx, nil, nil); err != nil {
+			t.Fatalf("execute failed: %v", err)
+		}
+	}
+	before := len(client.executed)
+	if _, err := exec.ExecuteTransaction(transfer("0xa4"), nil, nil); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	refill = client.executed[before]
+	if len(refill.GasData.Payment) != 4 {
+		t.Fatalf("expected refill to merge the source coin and 3 retired coins, got %+v", refill.GasData.Payment)
+	}
+	for _, ref := range refill.GasData.Payment {
+		if ref.ObjectID != utils.NormalizeSuiObjectID("0x100") && !used[ref.ObjectID] {
+			t.Fatalf("unexpected refill payment coin %s", ref.ObjectID)
+		}
+	}
+}
+
+type ctxKey struct{}
+
+// contextCore records the request value of the context of every call.
+type contextCore struct {
+	mockCore
+	mu     sync.Mutex
+	values map[string][]any
+}
+
+func (c *contextCore) Call(ctx context.Context, method string, params []any, out any) error {
+	c.mu.Lock()
+	if c.values == nil {
+		c.values = map[string][]any{}
+	}
+	c.values[method] = append(c.values[method], ctx.Value(ctxKey{}))
+	c.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.mockCore.Call(ctx, method, params, out)
+}
+
+func TestExecutorsThreadContext(t *testing.T) {
+	signer, _ := edkp.Generate()
+	newTx := func() *Transaction {
+		tx := NewTransaction()
+		tx.TransferObjects([]Argument{tx.Object(Inputs.ObjectRef(ObjectRef{ObjectID: "0xa", Version: "1", Digest: testDigest}))}, recipient(t, tx))
+		return tx
+	}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+
+	client := &contextCore{}
+	serial := NewSerialTransactionExecutor(SerialTransactionExecutorOptions{Client: client, Signer: signer})
+	if _, err := serial.ExecuteTransactionContext(ctx, newTx(), nil, nil); err != nil {
+		t.Fatalf("serial execute failed: %v", err)
+	}
+	if err := serial.WaitForLastTransactionContext(ctx); err != nil {
+		t.Fatalf("serial wait failed: %v", err)
+	}
+	parallel := NewParallelTransactionExecutor(ParallelTransactionExecutorOptions{Client: client, Signer: signer})
+	if _, err := parallel.ExecuteTransactionContext(ctx, newTx(), nil, nil); err != nil {
+		t.Fatalf("parallel execute failed: %v", err)
+	}
+	for _, method := range []string{"suix_getReferenceGasPrice", "suix_getCoins", "sui_executeTransactionBlock", "sui_getTransactionBlock"} {
+		if len(client.values[method]) == 0 {
+			t.Fatalf("expected %s to be called", method)
+		}
+		for _, v := range client.values[method] {
+			if v != "request" {
+				t.Fatalf("%s was called without the request context", method)
+			}
+		}
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	client.values = nil
+	if _, err := serial.ExecuteTransactionContext(cancelled, newTx(), nil, nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancelled serial execution, got %v", err)
+	}
+	if _, err := parallel.ExecuteTransactionContext(cancelled, newTx(), nil, nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancelled parallel execution, got %v", err)
+	}
+	if _, err := newTx().BuildContext(cancelled, BuildTransactionOptions{Client: client}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancelled build, got %v", err)
+	}
+	if len(client.values["sui_executeTransactionBlock"]) != 0 {
+		t.Fatalf("cancelled transactions must not be executed")
+	}
+}
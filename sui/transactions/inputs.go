@@ -1,35 +1,213 @@
 package transactions
 
 import (
-	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
 
 	"github.com/sui-sdks/go-sdks/sui/utils"
 )
 
 type ObjectRef struct {
 	ObjectID string `json:"objectId"`
+	Version  string `json:"version"`
 	Digest   string `json:"digest"`
-	Version  any    `json:"version"`
 }
 
-type CallArg map[string]any
+func (r *ObjectRef) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		ObjectID string  `json:"objectId"`
+		Version  jsonU64 `json:"version"`
+		Digest   string  `json:"digest"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*r = ObjectRef{ObjectID: aux.ObjectID, Version: string(aux.Version), Digest: aux.Digest}
+	return nil
+}
+
+type SharedObjectRef struct {
+	ObjectID             string `json:"objectId"`
+	InitialSharedVersion string `json:"initialSharedVersion"`
+	Mutable              bool   `json:"mutable"`
+}
+
+func (r *SharedObjectRef) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		ObjectID             string  `json:"objectId"`
+		InitialSharedVersion jsonU64 `json:"initialSharedVersion"`
+		Mutable              bool    `json:"mutable"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*r = SharedObjectRef{ObjectID: aux.ObjectID, InitialSharedVersion: string(aux.InitialSharedVersion), Mutable: aux.Mutable}
+	return nil
+}
+
+type ObjectArg struct {
+	ImmOrOwnedObject *ObjectRef       `json:"ImmOrOwnedObject,omitempty"`
+	SharedObject     *SharedObjectRef `json:"SharedObject,omitempty"`
+	Receiving        *ObjectRef       `json:"Receiving,omitempty"`
+}
+
+func (o ObjectArg) Kind() string {
+	switch {
+	case o.ImmOrOwnedObject != nil:
+		return "ImmOrOwnedObject"
+	case o.SharedObject != nil:
+		return "SharedObject"
+	case o.Receiving != nil:
+		return "Receiving"
+	default:
+		return ""
+	}
+}
+
+func (o ObjectArg) MarshalJSON() ([]byte, error) {
+	switch o.Kind() {
+	case "ImmOrOwnedObject":
+		return marshalEnum("ImmOrOwnedObject", o.ImmOrOwnedObject)
+	case "SharedObject":
+		return marshalEnum("SharedObject", o.SharedObject)
+	case "Receiving":
+		return marshalEnum("Receiving", o.Receiving)
+	default:
+		return nil, fmt.Errorf("empty object argument")
+	}
+}
+
+type PureArg struct {
+	Bytes []byte `json:"bytes"`
+}
+
+type UnresolvedPure struct {
+	Value any `json:"value"`
+}
+
+type UnresolvedObject struct {
+	ObjectID             string  `json:"objectId"`
+	Version              *string `json:"version,omitempty"`
+	Digest               *string `json:"digest,omitempty"`
+	InitialSharedVersion *string `json:"initialSharedVersion,omitempty"`
+	Mutable              *bool   `json:"mutable,omitempty"`
+}
+
+func (o *UnresolvedObject) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		ObjectID             string   `json:"objectId"`
+		Version              *jsonU64 `json:"version"`
+		Digest               *string  `json:"digest"`
+		InitialSharedVersion *jsonU64 `json:"initialSharedVersion"`
+		Mutable              *bool    `json:"mutable"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*o = UnresolvedObject{ObjectID: aux.ObjectID, Digest: aux.Digest, Mutable: aux.Mutable}
+	if aux.Version != nil {
+		v := string(*aux.Version)
+		o.Version = &v
+	}
+	if aux.InitialSharedVersion != nil {
+		v := string(*aux.InitialSharedVersion)
+		o.InitialSharedVersion = &v
+	}
+	return nil
+}
+
+// CallArg is a transaction input. Exactly one field is set; the Unresolved
+// variants must be resolved before the transaction can be built.
+type CallArg struct {
+	Object           *ObjectArg        `json:"Object,omitempty"`
+	Pure             *PureArg          `json:"Pure,omitempty"`
+	UnresolvedPure   *UnresolvedPure   `json:"UnresolvedPure,omitempty"`
+	UnresolvedObject *UnresolvedObject `json:"UnresolvedObject,omitempty"`
+}
+
+func (c CallArg) Kind() string {
+	switch {
+	case c.Object != nil:
+		return "Object"
+	case c.Pure != nil:
+		return "Pure"
+	case c.UnresolvedPure != nil:
+		return "UnresolvedPure"
+	case c.UnresolvedObject != nil:
+		return "UnresolvedObject"
+	default:
+		return ""
+	}
+}
+
+func (c CallArg) MarshalJSON() ([]byte, error) {
+	switch c.Kind() {
+	case "Object":
+		return marshalEnum("Object", c.Object)
+	case "Pure":
+		return marshalEnum("Pure", c.Pure)
+	case "UnresolvedPure":
+		return marshalEnum("UnresolvedPure", c.UnresolvedPure)
+	case "UnresolvedObject":
+		return marshalEnum("UnresolvedObject", c.UnresolvedObject)
+	default:
+		return nil, fmt.Errorf("empty call argument")
+	}
+}
 
 var Inputs = struct {
 	Pure            func([]byte) CallArg
 	ObjectRef       func(ObjectRef) CallArg
-	SharedObjectRef func(objectID string, mutable bool, initialSharedVersion any) CallArg
+	SharedObjectRef func(objectID string, mutable bool, initialSharedVersion string) CallArg
 	ReceivingRef    func(ObjectRef) CallArg
 }{
 	Pure: func(data []byte) CallArg {
-		return CallArg{"$kind": "Pure", "Pure": map[string]any{"bytes": base64.StdEncoding.EncodeToString(data)}}
+		return CallArg{Pure: &PureArg{Bytes: append([]byte(nil), data...)}}
 	},
 	ObjectRef: func(ref ObjectRef) CallArg {
-		return CallArg{"$kind": "Object", "Object": map[string]any{"$kind": "ImmOrOwnedObject", "ImmOrOwnedObject": map[string]any{"objectId": utils.NormalizeSuiAddress(ref.ObjectID), "digest": ref.Digest, "version": ref.Version}}}
+		ref.ObjectID = utils.NormalizeSuiAddress(ref.ObjectID)
+		return CallArg{Object: &ObjectArg{ImmOrOwnedObject: &ref}}
 	},
-	SharedObjectRef: func(objectID string, mutable bool, initialSharedVersion any) CallArg {
-		return CallArg{"$kind": "Object", "Object": map[string]any{"$kind": "SharedObject", "SharedObject": map[string]any{"objectId": utils.NormalizeSuiAddress(objectID), "mutable": mutable, "initialSharedVersion": initialSharedVersion}}}
+	SharedObjectRef: func(objectID string, mutable bool, initialSharedVersion string) CallArg {
+		return CallArg{Object: &ObjectArg{SharedObject: &SharedObjectRef{
+			ObjectID:             utils.NormalizeSuiAddress(objectID),
+			InitialSharedVersion: initialSharedVersion,
+			Mutable:              mutable,
+		}}}
 	},
 	ReceivingRef: func(ref ObjectRef) CallArg {
-		return CallArg{"$kind": "Object", "Object": map[string]any{"$kind": "Receiving", "Receiving": map[string]any{"objectId": utils.NormalizeSuiAddress(ref.ObjectID), "digest": ref.Digest, "version": ref.Version}}}
+		ref.ObjectID = utils.NormalizeSuiAddress(ref.ObjectID)
+		return CallArg{Object: &ObjectArg{Receiving: &ref}}
 	},
 }
+
+func marshalEnum(kind string, value any) ([]byte, error) {
+	return json.Marshal(map[string]any{"$kind": kind, kind: value})
+}
+
+// jsonU64 accepts u64 values encoded either as JSON strings or JSON numbers.
+type jsonU64 string
+
+func (v *jsonU64) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		if _, err := strconv.ParseUint(s, 10, 64); err != nil {
+			return fmt.Errorf("invalid u64 value: %q", s)
+		}
+		*v = jsonU64(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	if _, err := strconv.ParseUint(n.String(), 10, 64); err != nil {
+		return fmt.Errorf("invalid u64 value: %s", n)
+	}
+	*v = jsonU64(n.String())
+	return nil
+}
@@ -0,0 +1,122 @@
+package transactions
+
+import "fmt"
+
+func hasIntents(transactionData *TransactionData) bool {
+	for _, cmd := range transactionData.Commands {
+		if cmd.Intent != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveIntents runs the resolver of every intent name used by the
+// transaction, in order of first use.
+func resolveIntents(transactionData *TransactionData, options BuildTransactionOptions, resolvers map[string]TransactionPlugin) error {
+	for {
+		name := ""
+		for _, cmd := range transactionData.Commands {
+			if cmd.Intent != nil {
+				name = cmd.Intent.Name
+				break
+			}
+		}
+		if name == "" {
+			return nil
+		}
+		resolver, ok := resolvers[name]
+		if !ok {
+			return fmt.Errorf("no intent resolver found for %s", name)
+		}
+		if err := resolver(transactionData, options, func() error { return nil }); err != nil {
+			return err
+		}
+		for _, cmd := range transactionData.Commands {
+			if cmd.Intent != nil && cmd.Intent.Name == name {
+				return fmt.Errorf("intent resolver for %s left intents unresolved", name)
+			}
+		}
+	}
+}
+
+// ReplaceCommand swaps the command at index for replacement. References to
+// the old command's result are redirected to result, and references to later
+// commands are shifted past the inserted commands.
+func (d *TransactionData) ReplaceCommand(index int, replacement []Command, result Argument) {
+	shift := len(replacement) - 1
+	commands := make([]Command, 0, len(d.Commands)+shift)
+	commands = append(commands, d.Commands[:index]...)
+	commands = append(commands, replacement...)
+	commands = append(commands, d.Commands[index+1:]...)
+	d.Commands = commands
+	for i := index + len(replacement); i < len(d.Commands); i++ {
+		d.Commands[i] = mapCommandArguments(d.Commands[i], func(arg Argument) Argument {
+			if arg.Kind != ArgumentResult && arg.Kind != ArgumentNestedResult {
+				return arg
+			}
+			switch {
+			case int(arg.Index) == index && arg.Kind == ArgumentResult:
+				return result
+			case int(arg.Index) == index:
+				if result.Kind == ArgumentNestedResult {
+					return NestedResultArgument(result.Index, result.ResultIndex+arg.ResultIndex)
+				}
+				return NestedResultArgument(result.Index, arg.ResultIndex)
+			case int(arg.Index) > index:
+				arg.Index = uint16(int(arg.Index) + shift)
+			}
+			return arg
+		})
+	}
+}
+
+// mapCommandArguments returns a copy of cmd with every argument passed
+// through f. The command's fields are copied so commands shared with other
+// transactions are left untouched.
+func mapCommandArguments(cmd Command, f func(Argument) Argument) Command {
+	mapAll := func(args []Argument) []Argument {
+		if args == nil {
+			return nil
+		}
+		out := make([]Argument, len(args))
+		for i, a := range args {
+			out[i] = f(a)
+		}
+		return out
+	}
+	switch {
+	case cmd.MoveCall != nil:
+		c := *cmd.MoveCall
+		c.Arguments = mapAll(c.Arguments)
+		return Command{MoveCall: &c}
+	case cmd.TransferObjects != nil:
+		c := *cmd.TransferObjects
+		c.Objects, c.Address = mapAll(c.Objects), f(c.Address)
+		return Command{TransferObjects: &c}
+	case cmd.SplitCoins != nil:
+		c := *cmd.SplitCoins
+		c.Coin, c.Amounts = f(c.Coin), mapAll(c.Amounts)
+		return Command{SplitCoins: &c}
+	case cmd.MergeCoins != nil:
+		c := *cmd.MergeCoins
+		c.Destination, c.Sources = f(c.Destination), mapAll(c.Sources)
+		return Command{MergeCoins: &c}
+	case cmd.MakeMoveVec != nil:
+		c := *cmd.MakeMoveVec
+		c.Elements = mapAll(c.Elements)
+		return Command{MakeMoveVec: &c}
+	case cmd.Upgrade != nil:
+		c := *cmd.Upgrade
+		c.Ticket = f(c.Ticket)
+		return Command{Upgrade: &c}
+	case cmd.Intent != nil:
+		c := *cmd.Intent
+		c.Inputs = make(map[string][]Argument, len(cmd.Intent.Inputs))
+		for name, args := range cmd.Intent.Inputs {
+			c.Inputs[name] = mapAll(args)
+		}
+		return Command{Intent: &c}
+	}
+	return cmd
+}
@@ -0,0 +1,165 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// ProtocolLimits are the Sui protocol limits a transaction is checked
+// against before it is serialized. A zero field is not checked.
+type ProtocolLimits struct {
+	MaxProgrammableTxCommands uint64
+	MaxInputObjects           uint64
+	MaxPureArgumentSize       uint64
+	MaxMoveVectorLen          uint64
+	MaxTypeArguments          uint64
+	MaxGasPaymentObjects      uint64
+	MaxTxSizeBytes            uint64
+}
+
+// DefaultProtocolLimits are used for any limit the fullnode does not report.
+var DefaultProtocolLimits = ProtocolLimits{
+	MaxProgrammableTxCommands: 1024,
+	MaxInputObjects:           2048,
+	MaxPureArgumentSize:       16 * 1024,
+	MaxMoveVectorLen:          256 * 1024,
+	MaxTypeArguments:          16,
+	MaxGasPaymentObjects:      256,
+	MaxTxSizeBytes:            128 * 1024,
+}
+
+// protocolLimitAttributes maps each limit to its protocol config attribute.
+var protocolLimitAttributes = []struct {
+	name  string
+	field func(*ProtocolLimits) *uint64
+}{
+	{"max_programmable_tx_commands", func(l *ProtocolLimits) *uint64 { return &l.MaxProgrammableTxCommands }},
+	{"max_input_objects", func(l *ProtocolLimits) *uint64 { return &l.MaxInputObjects }},
+	{"max_pure_argument_size", func(l *ProtocolLimits) *uint64 { return &l.MaxPureArgumentSize }},
+	{"max_move_vector_len", func(l *ProtocolLimits) *uint64 { return &l.MaxMoveVectorLen }},
+	{"max_type_arguments", func(l *ProtocolLimits) *uint64 { return &l.MaxTypeArguments }},
+	{"max_gas_payment_objects", func(l *ProtocolLimits) *uint64 { return &l.MaxGasPaymentObjects }},
+	{"max_tx_size_bytes", func(l *ProtocolLimits) *uint64 { return &l.MaxTxSizeBytes }},
+}
+
+// FetchProtocolLimits reads the limits of the current protocol version with
+// sui_getProtocolConfig. Limits missing from the response keep their
+// DefaultProtocolLimits value.
+func FetchProtocolLimits(ctx context.Context, client CoreClient) (ProtocolLimits, error) {
+	limits := DefaultProtocolLimits
+	var out map[string]any
+	if err := client.Call(ctx, "sui_getProtocolConfig", []any{}, &out); err != nil {
+		return limits, err
+	}
+	attributes, _ := out["attributes"].(map[string]any)
+	for _, attr := range protocolLimitAttributes {
+		// Values are tagged with their type, such as {"u64": "131072"}.
+		value, _ := attributes[attr.name].(map[string]any)
+		for _, v := range value {
+			s, err := toU64String(v)
+			if err != nil {
+				return DefaultProtocolLimits, fmt.Errorf("invalid protocol config %s: %w", attr.name, err)
+			}
+			*attr.field(&limits), _ = strconv.ParseUint(s, 10, 64)
+		}
+	}
+	return limits, nil
+}
+
+// protocolLimits returns the limits a build is checked against: options.Limits
+// when set, otherwise those reported by the client if options.FetchLimits is
+// set, falling back to DefaultProtocolLimits.
+func protocolLimits(options BuildTransactionOptions) (ProtocolLimits, error) {
+	if options.Limits != nil {
+		return *options.Limits, nil
+	}
+	if !options.FetchLimits || options.Client == nil {
+		return DefaultProtocolLimits, nil
+	}
+	ctx := options.Context()
+	limits, err := FetchProtocolLimits(ctx, options.Client)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ProtocolLimits{}, ctx.Err()
+		}
+		return DefaultProtocolLimits, nil
+	}
+	return limits, nil
+}
+
+// LimitError reports a transaction that exceeds a protocol limit. Command and
+// Input are the index of the offending command or input, or -1.
+type LimitError struct {
+	Limit   string
+	Max     uint64
+	Actual  uint64
+	Command int
+	Input   int
+}
+
+func (e *LimitError) Error() string {
+	switch {
+	case e.Command >= 0:
+		return fmt.Sprintf("command %d exceeds %s: %d > %d", e.Command, e.Limit, e.Actual, e.Max)
+	case e.Input >= 0:
+		return fmt.Sprintf("input %d exceeds %s: %d > %d", e.Input, e.Limit, e.Actual, e.Max)
+	default:
+		return fmt.Sprintf("transaction exceeds %s: %d > %d", e.Limit, e.Actual, e.Max)
+	}
+}
+
+func exceeds(max uint64, n int) bool {
+	return max > 0 && uint64(n) > max
+}
+
+// validateLimits checks resolved transaction data against limits. Gas
+// payment is only checked for full transactions.
+func validateLimits(data *TransactionData, limits ProtocolLimits, onlyKind bool) error {
+	if exceeds(limits.MaxProgrammableTxCommands, len(data.Commands)) {
+		return &LimitError{Limit: "max_programmable_tx_commands", Max: limits.MaxProgrammableTxCommands, Actual: uint64(len(data.Commands)), Command: int(limits.MaxProgrammableTxCommands), Input: -1}
+	}
+	// Object inputs and gas payment objects count towards max_input_objects,
+	// pure inputs do not.
+	inputObjects, overflow := 0, -1
+	for i, input := range data.Inputs {
+		if input.Object == nil && input.UnresolvedObject == nil {
+			continue
+		}
+		inputObjects++
+		if overflow < 0 && exceeds(limits.MaxInputObjects, inputObjects) {
+			overflow = i
+		}
+	}
+	if !onlyKind {
+		inputObjects += len(data.GasData.Payment)
+	}
+	if exceeds(limits.MaxInputObjects, inputObjects) {
+		return &LimitError{Limit: "max_input_objects", Max: limits.MaxInputObjects, Actual: uint64(inputObjects), Command: -1, Input: overflow}
+	}
+	for i, input := range data.Inputs {
+		if input.Pure != nil && exceeds(limits.MaxPureArgumentSize, len(input.Pure.Bytes)) {
+			return &LimitError{Limit: "max_pure_argument_size", Max: limits.MaxPureArgumentSize, Actual: uint64(len(input.Pure.Bytes)), Command: -1, Input: i}
+		}
+	}
+	for i, cmd := range data.Commands {
+		switch {
+		case cmd.MoveCall != nil && exceeds(limits.MaxTypeArguments, len(cmd.MoveCall.TypeArguments)):
+			return &LimitError{Limit: "max_type_arguments", Max: limits.MaxTypeArguments, Actual: uint64(len(cmd.MoveCall.TypeArguments)), Command: i, Input: -1}
+		case cmd.MakeMoveVec != nil && exceeds(limits.MaxMoveVectorLen, len(cmd.MakeMoveVec.Elements)):
+			return &LimitError{Limit: "max_move_vector_len", Max: limits.MaxMoveVectorLen, Actual: uint64(len(cmd.MakeMoveVec.Elements)), Command: i, Input: -1}
+		}
+	}
+	if !onlyKind && exceeds(limits.MaxGasPaymentObjects, len(data.GasData.Payment)) {
+		return &LimitError{Limit: "max_gas_payment_objects", Max: limits.MaxGasPaymentObjects, Actual: uint64(len(data.GasData.Payment)), Command: -1, Input: -1}
+	}
+	return nil
+}
+
+// validateTransactionSize checks the serialized size of a full transaction.
+func validateTransactionSize(bytes []byte, limits ProtocolLimits) error {
+	if exceeds(limits.MaxTxSizeBytes, len(bytes)) {
+		return &LimitError{Limit: "max_tx_size_bytes", Max: limits.MaxTxSizeBytes, Actual: uint64(len(bytes)), Command: -1, Input: -1}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type protocolConfigClient struct {
+	attributes map[string]any
+	err        error
+	calls      int
+}
+
+func (c *protocolConfigClient) Call(ctx context.Context, method string, params []any, out any) error {
+	if method != "sui_getProtocolConfig" {
+		return errors.New("unexpected method " + method)
+	}
+	c.calls++
+	if c.err != nil {
+		return c.err
+	}
+	*out.(*map[string]any) = map[string]any{"protocolVersion": "70", "attributes": c.attributes}
+	return nil
+}
+
+func TestFetchProtocolLimits(t *testing.T) {
+	client := &protocolConfigClient{attributes: map[string]any{
+		"max_programmable_tx_commands": map[string]any{"u32": "10"},
+		"max_tx_size_bytes":            map[string]any{"u64": "2048"},
+		"max_move_vector_len":          nil,
+	}}
+	limits, err := FetchProtocolLimits(context.Background(), client)
+	if err != nil {
+		t.Fatalf("fetch failed: %v", err)
+	}
+	want := DefaultProtocolLimits
+	want.MaxProgrammableTxCommands = 10
+	want.MaxTxSizeBytes = 2048
+	if limits != want {
+		t.Fatalf("expected %+v, got %+v", want, limits)
+	}
+}
+
+// resolvedTransaction returns a transaction that needs no client to build.
+func resolvedTransaction() *Transaction {
+	tx := NewTransaction()
+	tx.SetSender("0x1")
+	tx.SetGasPrice(1000)
+	tx.SetGasBudget(1000000)
+	tx.SetGasPayment([]ObjectRef{{ObjectID: "0x100", Version: "1", Digest: testDigest}})
+	return tx
+}
+
+func expectLimitError(t *testing.T, err error, limit string, command, input int) {
+	t.Helper()
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("expected a limit error for %s, got %v", limit, err)
+	}
+	if limitErr.Limit != limit || limitErr.Command != command || limitErr.Input != input {
+		t.Fatalf("expected %s at command %d, input %d, got %+v", limit, command, input, limitErr)
+	}
+}
+
+func TestBuildValidatesProtocolLimits(t *testing.T) {
+	limits := ProtocolLimits{
+		MaxProgrammableTxCommands: 2,
+		MaxInputObjects:           3,
+		MaxPureArgumentSize:       8,
+		MaxMoveVectorLen:          2,
+		MaxTypeArguments:          1,
+		MaxGasPaymentObjects:      1,
+		MaxTxSizeBytes:            4096,
+	}
+	opts := BuildTransactionOptions{Limits: &limits}
+
+	tx := resolvedTransaction()
+	for i := 0; i < 3; i++ {
+		tx.SplitCoins(tx.Gas(), []Argument{tx.PureBytes([]byte{1})})
+	}
+	_, err := tx.Build(opts)
+	expectLimitError(t, err, "max_programmable_tx_commands", 2, -1)
+
+	objects := func(tx *Transaction, n int) []Argument {
+		args := make([]Argument, n)
+		for i := range args {
+			args[i] = tx.Object(Inputs.ObjectRef(ObjectRef{ObjectID: fmt.Sprintf("0x%x", 0xa0+i), Version: "1", Digest: testDigest}))
+		}
+		return args
+	}
+	// Leave room for the 32-byte recipient.
+	objectLimits := limits
+	objectLimits.MaxPureArgumentSize = 32
+	objectOpts := BuildTransactionOptions{Limits: &objectLimits}
+	tx = resolvedTransaction()
+	tx.TransferObjects(objects(tx, 4), recipient(t, tx))
+	_, err = tx.Build(objectOpts)
+	expectLimitError(t, err, "max_input_objects", -1, 3)
+
+	// The gas payment counts towards the limit, pure inputs do not.
+	tx = resolvedTransaction()
+	tx.TransferObjects(objects(tx, 3), recipient(t, tx))
+	_, err = tx.Build(objectOpts)
+	expectLimitError(t, err, "max_input_objects", -1, -1)
+	if _, err := tx.Build(BuildTransactionOptions{Limits: &objectLimits, OnlyTransactionKind: true}); err != nil {
+		t.Fatalf("gas payment must not count for transaction kinds: %v", err)
+	}
+	tx = resolvedTransaction()
+	tx.SplitCoins(tx.Gas(), []Argument{tx.PureBytes([]byte{1}), tx.PureBytes([]byte{2}), tx.PureBytes([]byte{3}), tx.PureBytes([]byte{4})})
+	if _, err := tx.Build(opts); err != nil {
+		t.Fatalf("pure inputs must not count towards max_input_objects: %v", err)
+	}
+
+	tx = resolvedTransaction()
+	tx.SplitCoins(tx.Gas(), []Argument{tx.PureBytes([]byte{1}), tx.PureBytes(make([]byte, 9))})
+	_, err = tx.Build(opts)
+	expectLimitError(t, err, "max_pure_argument_size", -1, 1)
+
+	tx = resolvedTransaction()
+	tx.SplitCoins(tx.Gas(), []Argument{tx.PureBytes([]byte{1})})
+	tx.MoveCall("0x2::test::generic", nil, []string{"u8", "u16"})
+	_, err = tx.Build(opts)
+	expectLimitError(t, err, "max_type_arguments", 1, -1)
+
+	tx = resolvedTransaction()
+	typ := "u8"
+	tx.AddCommand(Command{MakeMoveVec: &MakeMoveVec{Type: &typ, Elements: []Argument{tx.PureBytes([]byte{1}), tx.PureBytes([]byte{2}), tx.PureBytes([]byte{3})}}})
+	_, err = tx.Build(opts)
+	expectLimitError(t, err, "max_move_vector_len", 0, -1)
+
+	tx = resolvedTransaction()
+	tx.SetGasPayment([]ObjectRef{{ObjectID: "0x100", Version: "1", Digest: testDigest}, {ObjectID: "0x101", Version: "1", Digest: testDigest}})
+	_, err = tx.Build(opts)
+	expectLimitError(t, err, "max_gas_payment_objects", -1, -1)
+	if _, err := tx.Build(BuildTransactionOptions{Limits: &limits, OnlyTransactionKind: true}); err != nil {
+		t.Fatalf("gas payment must not be checked for transaction kinds: %v", err)
+	}
+
+	tx = resolvedTransaction()
+	tx.PureBytes(make([]byte, 8))
+	small := limits
+	small.MaxTxSizeBytes = 64
+	_, err = tx.Build(BuildTransactionOptions{Limits: &small})
+	expectLimitError(t, err, "max_tx_size_bytes", -1, -1)
+}
+
+func TestBuildFetchesProtocolLimits(t *testing.T) {
+	tx := resolvedTransaction()
+	tx.SplitCoins(tx.Gas(), []Argument{tx.PureBytes([]byte{1})})
+	tx.SplitCoins(tx.Gas(), []Argument{tx.PureBytes([]byte{1})})
+
+	client := &protocolConfigClient{attributes: map[string]any{"max_programmable_tx_commands": map[string]any{"u32": "1"}}}
+	// Limits are only fetched when asked for.
+	if _, err := tx.Build(BuildTransactionOptions{Client: client}); err != nil || client.calls != 0 {
+		t.Fatalf("expected the default limits without a fetch, got %v after %d calls", err, client.calls)
+	}
+	_, err := tx.Build(BuildTransactionOptions{Client: client, FetchLimits: true})
+	expectLimitError(t, err, "max_programmable_tx_commands", 1, -1)
+
+	// Without a protocol config the static limits apply.
+	client.err = errors.New("method not found")
+	if _, err := tx.Build(BuildTransactionOptions{Client: client, FetchLimits: true}); err != nil {
+		t.Fatalf("expected the default limits to allow the transaction, got %v", err)
+	}
+}
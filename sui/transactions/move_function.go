@@ -0,0 +1,216 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+// moveCallParameters fetches the normalized parameter types of the MoveCall
+// commands selected by include, keyed by command index. The trailing TxContext
+// parameter is dropped so the types line up with the call's arguments.
+func moveCallParameters(ctx context.Context, transactionData *TransactionData, client CoreClient, include func(*MoveCall) bool) (map[int][]any, error) {
+	out := map[int][]any{}
+	byTarget := map[string][]any{}
+	for i, cmd := range transactionData.Commands {
+		call := cmd.MoveCall
+		if call == nil || !include(call) {
+			continue
+		}
+		target := call.Package + "::" + call.Module + "::" + call.Function
+		params, ok := byTarget[target]
+		if !ok {
+			var fn map[string]any
+			if err := client.Call(ctx, "sui_getNormalizedMoveFunction", []any{call.Package, call.Module, call.Function}, &fn); err != nil {
+				return nil, fmt.Errorf("failed to fetch move function %s: %w", target, err)
+			}
+			params, _ = fn["parameters"].([]any)
+			if n := len(params); n > 0 && isTxContextType(params[n-1]) {
+				params = params[:n-1]
+			}
+			byTarget[target] = params
+		}
+		out[i] = params
+	}
+	return out, nil
+}
+
+// moveReference splits a normalized Move type into its reference kind
+// ("Reference", "MutableReference" or "" for by-value) and the referenced type.
+func moveReference(t any) (string, any) {
+	if m, ok := t.(map[string]any); ok {
+		if inner, ok := m["Reference"]; ok {
+			return "Reference", inner
+		}
+		if inner, ok := m["MutableReference"]; ok {
+			return "MutableReference", inner
+		}
+	}
+	return "", t
+}
+
+// moveStruct returns the struct body of a normalized Move struct type.
+func moveStruct(t any) (map[string]any, bool) {
+	m, ok := t.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	st, ok := m["Struct"].(map[string]any)
+	return st, ok
+}
+
+func isMoveStruct(t any, address, module, name string) bool {
+	st, ok := moveStruct(t)
+	if !ok {
+		return false
+	}
+	addr, _ := st["address"].(string)
+	return utils.NormalizeSuiAddress(addr) == utils.NormalizeSuiAddress(address) && st["module"] == module && st["name"] == name
+}
+
+func isTxContextType(t any) bool {
+	_, inner := moveReference(t)
+	return isMoveStruct(inner, "0x2", "tx_context", "TxContext")
+}
+
+func isReceivingType(t any) bool {
+	return isMoveStruct(t, "0x2", "transfer", "Receiving")
+}
+
+func isInputArgument(arg Argument, index int) bool {
+	return arg.Kind == ArgumentInput && int(arg.Index) == index
+}
+
+// isUsedAsMutable reports whether any command needs the input at index by
+// mutable reference or by value. Calls without a known signature count as
+// mutable, since marking a shared object mutable is always accepted.
+func isUsedAsMutable(transactionData *TransactionData, index int, params map[int][]any) bool {
+	for i, cmd := range transactionData.Commands {
+		switch {
+		case cmd.MoveCall != nil:
+			types := params[i]
+			for j, arg := range cmd.MoveCall.Arguments {
+				if !isInputArgument(arg, index) {
+					continue
+				}
+				if j >= len(types) {
+					return true
+				}
+				if ref, _ := moveReference(types[j]); ref != "Reference" {
+					return true
+				}
+			}
+		case cmd.TransferObjects != nil, cmd.SplitCoins != nil, cmd.MergeCoins != nil, cmd.MakeMoveVec != nil:
+			for _, arg := range cmd.Arguments() {
+				if isInputArgument(arg, index) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isUsedAsReceiving reports whether a MoveCall takes the input at index as a
+// 0x2::transfer::Receiving<T> parameter.
+func isUsedAsReceiving(transactionData *TransactionData, index int, params map[int][]any) bool {
+	for i, cmd := range transactionData.Commands {
+		if cmd.MoveCall == nil {
+			continue
+		}
+		types := params[i]
+		for j, arg := range cmd.MoveCall.Arguments {
+			if isInputArgument(arg, index) && j < len(types) && isReceivingType(types[j]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pureTypeFromMove maps a normalized Move parameter type to a pure type name
+// understood by serializePure. Type parameters are substituted from typeArgs.
+func pureTypeFromMove(t any, typeArgs []string) (string, bool) {
+	_, t = moveReference(t)
+	switch v := t.(type) {
+	case string:
+		switch v {
+		case "Bool", "U8", "U16", "U32", "U64", "U128", "U256", "Address":
+			return strings.ToLower(v), true
+		}
+	case map[string]any:
+		if inner, ok := v["Vector"]; ok {
+			elem, ok := pureTypeFromMove(inner, typeArgs)
+			return "vector<" + elem + ">", ok
+		}
+		if param, ok := v["TypeParameter"]; ok {
+			idx, err := toU64String(param)
+			n, _ := strconv.Atoi(idx)
+			if err != nil || n >= len(typeArgs) {
+				return "", false
+			}
+			return pureTypeFromTypeTag(typeArgs[n])
+		}
+		st, ok := moveStruct(t)
+		if !ok {
+			return "", false
+		}
+		switch {
+		case isMoveStruct(t, "0x1", "string", "String"), isMoveStruct(t, "0x1", "ascii", "String"):
+			return "string", true
+		case isMoveStruct(t, "0x2", "object", "ID"):
+			return "id", true
+		case isMoveStruct(t, "0x1", "option", "Option"):
+			params, _ := st["typeArguments"].([]any)
+			if len(params) != 1 {
+				return "", false
+			}
+			inner, ok := pureTypeFromMove(params[0], typeArgs)
+			return "option<" + inner + ">", ok
+		}
+	}
+	return "", false
+}
+
+// pureTypeFromTypeTag maps a type argument such as "u64" or
+// "0x1::option::Option<address>" to a pure type name.
+func pureTypeFromTypeTag(tag string) (string, bool) {
+	parsed, err := parseTypeTag(tag)
+	if err != nil {
+		return "", false
+	}
+	return pureTypeFromParsedTag(parsed)
+}
+
+func pureTypeFromParsedTag(tag map[string]any) (string, bool) {
+	kind, _ := tag["$kind"].(string)
+	switch kind {
+	case "bool", "u8", "u16", "u32", "u64", "u128", "u256", "address":
+		return kind, true
+	case "vector":
+		inner, _ := tag["vector"].(map[string]any)
+		elem, ok := pureTypeFromParsedTag(inner)
+		return "vector<" + elem + ">", ok
+	case "struct":
+		st, _ := tag["struct"].(map[string]any)
+		name := toString(st["address"]) + "::" + toString(st["module"]) + "::" + toString(st["name"])
+		switch name {
+		case utils.NormalizeSuiAddress("0x1") + "::string::String", utils.NormalizeSuiAddress("0x1") + "::ascii::String":
+			return "string", true
+		case utils.NormalizeSuiAddress("0x2") + "::object::ID":
+			return "id", true
+		case utils.NormalizeSuiAddress("0x1") + "::option::Option":
+			params, _ := st["typeParams"].([]any)
+			if len(params) != 1 {
+				return "", false
+			}
+			inner, _ := params[0].(map[string]any)
+			elem, ok := pureTypeFromParsedTag(inner)
+			return "option<" + elem + ">", ok
+		}
+	}
+	return "", false
+}
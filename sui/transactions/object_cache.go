@@ -1,6 +1,10 @@
 package transactions
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
 
 type ObjectCache struct {
 	mu      sync.RWMutex
@@ -61,3 +65,74 @@ func (c *ObjectCache) ClearCustom() {
 	defer c.mu.Unlock()
 	c.custom = map[string]any{}
 }
+
+// ApplyEffects updates the cache from JSON-RPC transaction effects: created,
+// mutated and unwrapped objects and the gas object get their new reference
+// and owner, while deleted and wrapped objects are removed.
+func (c *ObjectCache) ApplyEffects(effects map[string]any) {
+	for _, field := range []string{"created", "mutated", "unwrapped"} {
+		changed, _ := effects[field].([]any)
+		for _, item := range changed {
+			c.applyOwnedObjectRef(item)
+		}
+	}
+	if gas, ok := effects["gasObject"]; ok {
+		c.applyOwnedObjectRef(gas)
+	}
+	for _, field := range []string{"deleted", "wrapped", "unwrappedThenDeleted"} {
+		removed, _ := effects[field].([]any)
+		for _, item := range removed {
+			ref, _ := item.(map[string]any)
+			if id := toString(ref["objectId"]); ref != nil && id != "" {
+				c.DeleteObject(utils.NormalizeSuiObjectID(id))
+			}
+		}
+	}
+}
+
+func (c *ObjectCache) applyOwnedObjectRef(item any) {
+	entry, _ := item.(map[string]any)
+	ref, _ := entry["reference"].(map[string]any)
+	if ref == nil {
+		return
+	}
+	id := utils.NormalizeSuiObjectID(toString(ref["objectId"]))
+	version, err := toU64String(ref["version"])
+	if err != nil {
+		return
+	}
+	c.SetObject(id, map[string]any{
+		"objectId": id,
+		"version":  version,
+		"digest":   toString(ref["digest"]),
+		"owner":    entry["owner"],
+	})
+}
+
+// AsPlugin returns a build plugin that fills unresolved object inputs from
+// the cache, so the resolver only fetches objects it has not seen.
+func (c *ObjectCache) AsPlugin() TransactionPlugin {
+	return func(transactionData *TransactionData, options BuildTransactionOptions, next func() error) error {
+		for i, input := range transactionData.Inputs {
+			obj := input.UnresolvedObject
+			if obj == nil {
+				continue
+			}
+			cached, ok := c.GetObject(utils.NormalizeSuiObjectID(obj.ObjectID))
+			if !ok {
+				continue
+			}
+			resolved := *obj
+			if v, ok := initialSharedVersionFromOwner(cached["owner"]); ok {
+				if resolved.InitialSharedVersion == nil {
+					resolved.InitialSharedVersion = &v
+				}
+			} else if resolved.Version == nil && resolved.Digest == nil {
+				version, digest := toString(cached["version"]), toString(cached["digest"])
+				resolved.Version, resolved.Digest = &version, &digest
+			}
+			transactionData.Inputs[i] = CallArg{UnresolvedObject: &resolved}
+		}
+		return next()
+	}
+}
@@ -0,0 +1,128 @@
+package transactions
+
+import (
+	"context"
+	"testing"
+
+	edkp "github.com/sui-sdks/go-sdks/sui/keypairs/ed25519"
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+func ownedRef(owner any, id string, version float64) map[string]any {
+	return map[string]any{"owner": owner, "reference": map[string]any{"objectId": id, "version": version, "digest": testDigest}}
+}
+
+func TestObjectCacheApplyEffects(t *testing.T) {
+	cache := NewObjectCache()
+	cache.SetObject(utils.NormalizeSuiObjectID("0xd"), map[string]any{"version": "1"})
+	cache.SetObject(utils.NormalizeSuiObjectID("0xe"), map[string]any{"version": "1"})
+	cache.ApplyEffects(map[string]any{
+		"created":   []any{ownedRef(map[string]any{"AddressOwner": "0x1"}, "0xa", 5)},
+		"mutated":   []any{ownedRef(map[string]any{"Shared": map[string]any{"initial_shared_version": float64(2)}}, "0xb", 5)},
+		"gasObject": ownedRef(map[string]any{"AddressOwner": "0x1"}, "0xc", 5),
+		"deleted":   []any{map[string]any{"objectId": "0xd", "version": float64(5), "digest": testDigest}},
+		"wrapped":   []any{map[string]any{"objectId": "0xe", "version": float64(5), "digest": testDigest}},
+	})
+	for _, id := range []string{"0xa", "0xb", "0xc"} {
+		obj, ok := cache.GetObject(utils.NormalizeSuiObjectID(id))
+		if !ok || obj["version"] != "5" || obj["digest"] != testDigest {
+			t.Fatalf("expected %s at version 5, got %v", id, obj)
+		}
+	}
+	for _, id := range []string{"0xd", "0xe"} {
+		if _, ok := cache.GetObject(utils.NormalizeSuiObjectID(id)); ok {
+			t.Fatalf("expected %s to be removed", id)
+		}
+	}
+}
+
+func TestObjectCachePluginSkipsFetches(t *testing.T) {
+	cache := NewObjectCache()
+	cache.ApplyEffects(map[string]any{
+		"mutated": []any{
+			ownedRef(map[string]any{"AddressOwner": "0x1"}, "0xa", 9),
+			ownedRef(map[string]any{"Shared": map[string]any{"initial_shared_version": float64(3)}}, "0xb", 9),
+		},
+	})
+	client := &resolverClient{objects: map[string]map[string]any{
+		utils.NormalizeSuiObjectID("0xc"): ownedObject(utils.NormalizeSuiObjectID("0xc"), "4"),
+	}}
+	tx := NewTransaction()
+	tx.TransferObjects([]Argument{tx.Object("0xa"), tx.Object("0xc")}, tx.Object("0xb"))
+	kind, err := tx.build(BuildTransactionOptions{Client: client, OnlyTransactionKind: true}, []TransactionPlugin{cache.AsPlugin()})
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+	resolved, err := TransactionFromKind(kind)
+	if err != nil {
+		t.Fatalf("parse built kind: %v", err)
+	}
+	if len(client.fetched) != 1 || len(client.fetched[0]) != 1 || client.fetched[0][0] != utils.NormalizeSuiObjectID("0xc") {
+		t.Fatalf("expected only the uncached object to be fetched, got %v", client.fetched)
+	}
+	inputs := resolved.GetData().Inputs
+	if ref := inputs[0].Object.ImmOrOwnedObject; ref == nil || ref.Version != "9" {
+		t.Fatalf("expected cached owned reference, got %+v", inputs[0])
+	}
+	if shared := inputs[2].Object.SharedObject; shared == nil || shared.InitialSharedVersion != "3" {
+		t.Fatalf("expected cached shared reference, got %+v", inputs[2])
+	}
+}
+
+// effectsCore answers execution with effects that bump the gas coin and an
+// owned object, and counts suix_getCoins calls.
+type effectsCore struct {
+	mockCore
+	owner     string
+	coinCalls int
+	include   map[string]any
+}
+
+func (c *effectsCore) Call(ctx context.Context, method string, params []any, out any) error {
+	switch method {
+	case "suix_getCoins":
+		c.coinCalls++
+	case "sui_executeTransactionBlock":
+		c.include = params[2].(map[string]any)
+		owner := map[string]any{"AddressOwner": c.owner}
+		*out.(*map[string]any) = map[string]any{"digest": "abc", "effects": map[string]any{
+			"mutated":   []any{ownedRef(owner, "0xa", 12)},
+			"gasObject": ownedRef(owner, "0x100", 12),
+		}}
+		return nil
+	}
+	return c.mockCore.Call(ctx, method, params, out)
+}
+
+func TestSerialExecutorReusesCachedEffects(t *testing.T) {
+	signer, _ := edkp.Generate()
+	client := &effectsCore{owner: signer.ToSuiAddress()}
+	exec := NewSerialTransactionExecutor(SerialTransactionExecutorOptions{Client: client, Signer: signer})
+
+	first := NewTransaction()
+	first.TransferObjects([]Argument{first.Object(Inputs.ObjectRef(ObjectRef{ObjectID: "0xa", Version: "11", Digest: testDigest}))}, recipient(t, first))
+	if _, err := exec.ExecuteTransaction(first, map[string]any{"showEvents": true}, nil); err != nil {
+		t.Fatalf("first execution failed: %v", err)
+	}
+	if client.include["showEffects"] != true || client.include["showEvents"] != true {
+		t.Fatalf("expected effects to be requested alongside caller options, got %v", client.include)
+	}
+	if client.coinCalls != 1 {
+		t.Fatalf("expected first transaction to select gas coins, got %d calls", client.coinCalls)
+	}
+
+	second := NewTransaction()
+	second.TransferObjects([]Argument{second.Object("0xa")}, recipient(t, second))
+	bytes, err := exec.BuildTransaction(second)
+	if err != nil {
+		t.Fatalf("second build failed: %v", err)
+	}
+	built, _ := TransactionFromBytes(bytes)
+	data := built.GetData()
+	if ref := data.Inputs[0].Object.ImmOrOwnedObject; ref == nil || ref.Version != "12" {
+		t.Fatalf("expected object version from effects, got %+v", data.Inputs[0])
+	}
+	if len(data.GasData.Payment) != 1 || data.GasData.Payment[0].Version != "12" || client.coinCalls != 1 {
+		t.Fatalf("expected cached gas coin to be reused, got %+v (%d coin calls)", data.GasData.Payment, client.coinCalls)
+	}
+}
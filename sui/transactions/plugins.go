@@ -0,0 +1,148 @@
+package transactions
+
+import (
+	"fmt"
+	"sync"
+)
+
+type namedPlugin struct {
+	name   string
+	plugin TransactionPlugin
+}
+
+// globalPlugins holds the build plugins and intent resolvers shared by every
+// transaction. CoinWithBalance is registered by default.
+var globalPlugins = struct {
+	mu        sync.RWMutex
+	build     []namedPlugin
+	resolvers map[string]TransactionPlugin
+}{
+	resolvers: map[string]TransactionPlugin{CoinWithBalanceIntent: resolveCoinWithBalance},
+}
+
+// RegisterGlobalBuildPlugin adds a build plugin that runs for every
+// transaction, before the transaction's own plugins. Registering an existing
+// name replaces that plugin in place.
+func RegisterGlobalBuildPlugin(name string, plugin TransactionPlugin) {
+	globalPlugins.mu.Lock()
+	defer globalPlugins.mu.Unlock()
+	for i, p := range globalPlugins.build {
+		if p.name == name {
+			globalPlugins.build[i].plugin = plugin
+			return
+		}
+	}
+	globalPlugins.build = append(globalPlugins.build, namedPlugin{name: name, plugin: plugin})
+}
+
+func UnregisterGlobalBuildPlugin(name string) {
+	globalPlugins.mu.Lock()
+	defer globalPlugins.mu.Unlock()
+	for i, p := range globalPlugins.build {
+		if p.name == name {
+			globalPlugins.build = append(globalPlugins.build[:i:i], globalPlugins.build[i+1:]...)
+			return
+		}
+	}
+}
+
+// RegisterGlobalIntentResolver sets the resolver used for intents with the
+// given name unless a transaction registers its own.
+func RegisterGlobalIntentResolver(name string, resolver TransactionPlugin) {
+	globalPlugins.mu.Lock()
+	defer globalPlugins.mu.Unlock()
+	globalPlugins.resolvers[name] = resolver
+}
+
+func UnregisterGlobalIntentResolver(name string) {
+	globalPlugins.mu.Lock()
+	defer globalPlugins.mu.Unlock()
+	delete(globalPlugins.resolvers, name)
+}
+
+// AddBuildPlugin adds a plugin that runs during Build, after the global
+// plugins and before the core resolver. Plugins run in the order added.
+func (t *Transaction) AddBuildPlugin(plugin TransactionPlugin) {
+	t.buildPlugins = append(t.buildPlugins, plugin)
+}
+
+// AddIntentResolver sets the resolver for intents with the given name,
+// overriding any global resolver for this transaction.
+func (t *Transaction) AddIntentResolver(name string, resolver TransactionPlugin) {
+	if t.intentResolvers == nil {
+		t.intentResolvers = map[string]TransactionPlugin{}
+	}
+	t.intentResolvers[name] = resolver
+}
+
+func globalIntentResolvers() map[string]TransactionPlugin {
+	globalPlugins.mu.RLock()
+	defer globalPlugins.mu.RUnlock()
+	out := make(map[string]TransactionPlugin, len(globalPlugins.resolvers))
+	for name, r := range globalPlugins.resolvers {
+		out[name] = r
+	}
+	return out
+}
+
+// buildPipeline returns the plugins Build runs, in order: global plugins, the
+// transaction's plugins, extra plugins supplied by an executor, then intent
+// and core resolution.
+func (t *Transaction) buildPipeline(options BuildTransactionOptions, extra []TransactionPlugin) ([]TransactionPlugin, BuildTransactionOptions) {
+	globalPlugins.mu.RLock()
+	plugins := make([]TransactionPlugin, 0, len(globalPlugins.build)+len(t.buildPlugins)+1)
+	for _, p := range globalPlugins.build {
+		plugins = append(plugins, p.plugin)
+	}
+	globalPlugins.mu.RUnlock()
+	resolvers := globalIntentResolvers()
+	for name, r := range t.intentResolvers {
+		resolvers[name] = r
+	}
+	options.intentResolvers = resolvers
+
+	plugins = append(plugins, t.buildPlugins...)
+	plugins = append(plugins, extra...)
+	plugins = append(plugins, func(transactionData *TransactionData, options BuildTransactionOptions, next func() error) error {
+		if options.Client == nil {
+			// Without a client only intents can be resolved; anything else
+			// left unresolved is reported when the transaction is serialized.
+			if err := resolveIntents(transactionData, options, options.intentResolvers); err != nil {
+				return err
+			}
+			return next()
+		}
+		return ResolveTransactionPlugin(transactionData, options, next)
+	})
+	return plugins, options
+}
+
+// runTransactionPlugins runs plugins as a middleware chain: each plugin gets a
+// next function that runs the rest of the chain and must call it exactly once.
+func runTransactionPlugins(transactionData *TransactionData, options BuildTransactionOptions, plugins []TransactionPlugin) error {
+	var run func(i int) error
+	run = func(i int) error {
+		if i == len(plugins) {
+			return nil
+		}
+		if err := options.Context().Err(); err != nil {
+			return err
+		}
+		called := false
+		err := plugins[i](transactionData, options, func() error {
+			if called {
+				return fmt.Errorf("next() was called multiple times in transaction plugin %d", i)
+			}
+			called = true
+			return run(i + 1)
+		})
+		if err != nil {
+			return err
+		}
+		if !called {
+			return fmt.Errorf("next() was not called in transaction plugin %d", i)
+		}
+		return nil
+	}
+	return run(0)
+}
@@ -0,0 +1,143 @@
+package transactions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func fullyResolvedTransaction() *Transaction {
+	tx := NewTransaction()
+	tx.SetSender("0x1")
+	tx.SetGasPrice(1)
+	tx.SetGasBudget(1)
+	tx.SetGasPayment([]ObjectRef{{ObjectID: "0x5", Version: "1", Digest: testDigest}})
+	return tx
+}
+
+func TestBuildPluginsRunAsMiddleware(t *testing.T) {
+	var order []string
+	trace := func(name string) TransactionPlugin {
+		return func(data *TransactionData, options BuildTransactionOptions, next func() error) error {
+			order = append(order, name+":before")
+			if err := next(); err != nil {
+				return err
+			}
+			order = append(order, name+":after")
+			return nil
+		}
+	}
+	RegisterGlobalBuildPlugin("test-global", trace("global"))
+	defer UnregisterGlobalBuildPlugin("test-global")
+
+	tx := fullyResolvedTransaction()
+	tx.AddBuildPlugin(trace("first"))
+	tx.AddBuildPlugin(func(data *TransactionData, options BuildTransactionOptions, next func() error) error {
+		// Plugins may edit the transaction before resolution runs.
+		data.GasData.Budget = "42"
+		return next()
+	})
+	tx.AddBuildPlugin(trace("second"))
+	built, err := tx.Build()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+	want := "global:before,first:before,second:before,second:after,first:after,global:after"
+	if got := strings.Join(order, ","); got != want {
+		t.Fatalf("unexpected plugin order:\n got %s\nwant %s", got, want)
+	}
+	if resolved, _ := TransactionFromBytes(built); resolved.GetData().GasData.Budget != "42" {
+		t.Fatalf("plugin edits were not applied")
+	}
+
+	UnregisterGlobalBuildPlugin("test-global")
+	order = nil
+	if _, err := fullyResolvedTransaction().Build(); err != nil || len(order) != 0 {
+		t.Fatalf("unregistered plugin still ran: %v %v", order, err)
+	}
+}
+
+func TestBuildPluginsMustCallNextOnce(t *testing.T) {
+	skip := fullyResolvedTransaction()
+	skip.AddBuildPlugin(func(*TransactionData, BuildTransactionOptions, func() error) error { return nil })
+	if _, err := skip.Build(); err == nil || !strings.Contains(err.Error(), "not called") {
+		t.Fatalf("expected error for plugin that skips next, got %v", err)
+	}
+
+	twice := fullyResolvedTransaction()
+	twice.AddBuildPlugin(func(_ *TransactionData, _ BuildTransactionOptions, next func() error) error {
+		_ = next()
+		return next()
+	})
+	if _, err := twice.Build(); err == nil || !strings.Contains(err.Error(), "multiple times") {
+		t.Fatalf("expected error for plugin that calls next twice, got %v", err)
+	}
+
+	blocked := fullyResolvedTransaction()
+	blocked.MoveCall("0x99::m::f", nil, nil)
+	blocked.AddBuildPlugin(func(data *TransactionData, _ BuildTransactionOptions, next func() error) error {
+		for i, cmd := range data.Commands {
+			if cmd.MoveCall != nil && cmd.MoveCall.Package != "0x0000000000000000000000000000000000000000000000000000000000000002" {
+				return fmt.Errorf("command %d calls a package outside the allow-list", i)
+			}
+		}
+		return next()
+	})
+	if _, err := blocked.Build(); err == nil || !strings.Contains(err.Error(), "command 0") {
+		t.Fatalf("expected allow-list rejection, got %v", err)
+	}
+}
+
+func TestIntentResolvers(t *testing.T) {
+	tip := func(amount uint64) TransactionPlugin {
+		return func(data *TransactionData, _ BuildTransactionOptions, next func() error) error {
+			for i := 0; i < len(data.Commands); i++ {
+				if intent := data.Commands[i].Intent; intent == nil || intent.Name != "Tip" {
+					continue
+				}
+				data.Inputs = append(data.Inputs, Inputs.Pure([]byte{byte(amount), 0, 0, 0, 0, 0, 0, 0}))
+				split := TransactionCommands.SplitCoins(GasCoinArgument(), []Argument{InputArgument(uint16(len(data.Inputs) - 1))})
+				data.ReplaceCommand(i, []Command{split}, NestedResultArgument(uint16(i), 0))
+			}
+			return next()
+		}
+	}
+	addTip := func(tx *Transaction) {
+		coin := tx.AddCommand(Command{Intent: &TransactionIntent{Name: "Tip", Inputs: map[string][]Argument{}, Data: map[string]any{}}})
+		tx.TransferObjects([]Argument{coin}, recipient(t, tx))
+	}
+
+	missing := fullyResolvedTransaction()
+	addTip(missing)
+	if _, err := missing.Build(); err == nil || !strings.Contains(err.Error(), "no intent resolver found for Tip") {
+		t.Fatalf("expected missing resolver error, got %v", err)
+	}
+
+	RegisterGlobalIntentResolver("Tip", tip(1))
+	defer UnregisterGlobalIntentResolver("Tip")
+	global := fullyResolvedTransaction()
+	addTip(global)
+	built, err := global.Build()
+	if err != nil {
+		t.Fatalf("build with global resolver failed: %v", err)
+	}
+	resolved, _ := TransactionFromBytes(built)
+	if got := pureInputHex(t, resolved, resolved.GetData().Commands[0].SplitCoins.Amounts[0]); got != "0100000000000000" {
+		t.Fatalf("unexpected global tip amount: %s", got)
+	}
+	if got := resolved.GetData().Commands[1].TransferObjects.Objects[0]; got != NestedResultArgument(0, 0) {
+		t.Fatalf("intent result was not remapped: %+v", got)
+	}
+
+	local := fullyResolvedTransaction()
+	local.AddIntentResolver("Tip", tip(2))
+	addTip(local)
+	built, err = local.Build()
+	if err != nil {
+		t.Fatalf("build with transaction resolver failed: %v", err)
+	}
+	resolved, _ = TransactionFromBytes(built)
+	if got := pureInputHex(t, resolved, resolved.GetData().Commands[0].SplitCoins.Amounts[0]); got != "0200000000000000" {
+		t.Fatalf("transaction resolver must override the global one, got %s", got)
+	}
+}
@@ -1,7 +1,7 @@
 package transactions
 
 import (
-	"encoding/base64"
+	"bytes"
 	"testing"
 )
 
@@ -22,13 +22,13 @@ func TestPTBBasicsSplitAndTransfer(t *testing.T) {
 	if len(data.Commands) != 3 {
 		t.Fatalf("expected 3 commands, got %d", len(data.Commands))
 	}
-	if got := data.Commands[0]["$kind"]; got != "SplitCoins" {
+	if got := data.Commands[0].Kind(); got != "SplitCoins" {
 		t.Fatalf("expected first command SplitCoins, got %v", got)
 	}
-	if got := data.Commands[1]["$kind"]; got != "TransferObjects" {
+	if got := data.Commands[1].Kind(); got != "TransferObjects" {
 		t.Fatalf("expected second command TransferObjects, got %v", got)
 	}
-	if got := data.Commands[2]["$kind"]; got != "MergeCoins" {
+	if got := data.Commands[2].Kind(); got != "MergeCoins" {
 		t.Fatalf("expected third command MergeCoins, got %v", got)
 	}
 }
@@ -55,12 +55,12 @@ func TestPTBBasicsMoveCallAndMakeMoveVec(t *testing.T) {
 		t.Fatalf("expected 3 commands, got %d", len(data.Commands))
 	}
 
-	moveCall := data.Commands[0]["MoveCall"].(map[string]any)
-	if moveCall["package"] != "0x2" || moveCall["module"] != "example" || moveCall["function"] != "do_something" {
+	moveCall := data.Commands[0].MoveCall
+	if moveCall.Package != "0x0000000000000000000000000000000000000000000000000000000000000002" || moveCall.Module != "example" || moveCall.Function != "do_something" {
 		t.Fatalf("unexpected move call target parsing: %+v", moveCall)
 	}
 
-	if got := data.Commands[1]["$kind"]; got != "MakeMoveVec" {
+	if got := data.Commands[1].Kind(); got != "MakeMoveVec" {
 		t.Fatalf("expected second command MakeMoveVec, got %v", got)
 	}
 }
@@ -87,9 +87,8 @@ func TestPTBBasicsPublishAndResolvedObjectRefs(t *testing.T) {
 	if len(data.Commands) != 1 {
 		t.Fatalf("expected 1 command, got %d", len(data.Commands))
 	}
-	pub := data.Commands[0]["Publish"].(map[string]any)
-	mods := pub["modules"].([]string)
-	if len(mods) != 1 || mods[0] != base64.StdEncoding.EncodeToString([]byte{0xAA, 0xBB}) {
+	mods := data.Commands[0].Publish.Modules
+	if len(mods) != 1 || !bytes.Equal(mods[0], []byte{0xAA, 0xBB}) {
 		t.Fatalf("unexpected publish modules: %+v", mods)
 	}
 	if len(data.Inputs) != 3 {
@@ -100,12 +99,23 @@ func TestPTBBasicsPublishAndResolvedObjectRefs(t *testing.T) {
 func TestPTBBasicsBuildBase64AndFrom(t *testing.T) {
 	tx := NewTransaction()
 	tx.SetSender("0x1")
+	tx.SetGasPrice(1)
+	tx.SetGasBudget(1_000_000)
 	tx.SplitCoins(tx.Gas(), []Argument{tx.PureBytes([]byte{9})})
 
 	b64, err := tx.BuildBase64()
 	if err != nil {
 		t.Fatalf("build base64 failed: %v", err)
 	}
+	parsed, err := BCS.TransactionData.FromBase64(b64)
+	if err != nil {
+		t.Fatalf("parse built base64 failed: %v", err)
+	}
+	kind := parsed.(map[string]any)["V1"].(map[string]any)["kind"].(map[string]any)
+	commands := kind["ProgrammableTransaction"].(map[string]any)["commands"].([]any)
+	if len(commands) != 1 {
+		t.Fatalf("expected built command count 1, got %d", len(commands))
+	}
 	restored, err := TransactionFrom(b64)
 	if err != nil {
 		t.Fatalf("transaction from base64 failed: %v", err)
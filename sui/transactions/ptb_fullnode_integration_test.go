@@ -3,7 +3,10 @@ package transactions
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -107,6 +110,64 @@ func TestPTBFullnodeIntegrationResolveBuild(t *testing.T) {
 	}
 }
 
+// TestPTBFullnodeIntegrationGoldenBytes decodes and rebuilds transactions
+// taken from the chain, which must give back their exact bytes and digest.
+// Set SUI_IT_TX_DIGESTS to a comma-separated list of digests, or the
+// transactions of the latest checkpoint are used. With SUI_IT_WRITE_GOLDENS=1
+// the checked transactions replace chainTransactionsFile.
+func TestPTBFullnodeIntegrationGoldenBytes(t *testing.T) {
+	cfg := loadFullnodeITConfig(t)
+	client := newITJSONRPCClient(t, cfg)
+	ctx := context.Background()
+
+	var digests []string
+	if v := os.Getenv("SUI_IT_TX_DIGESTS"); v != "" {
+		digests = strings.Split(v, ",")
+	} else {
+		var seq string
+		if err := client.Call(ctx, "sui_getLatestCheckpointSequenceNumber", []any{}, &seq); err != nil {
+			t.Fatalf("get latest checkpoint failed: %v", err)
+		}
+		var checkpoint struct {
+			Transactions []string `json:"transactions"`
+		}
+		if err := client.Call(ctx, "sui_getCheckpoint", []any{seq}, &checkpoint); err != nil {
+			t.Fatalf("get checkpoint %s failed: %v", seq, err)
+		}
+		digests = checkpoint.Transactions
+	}
+
+	var checked []chainTransaction
+	for _, digest := range digests {
+		digest = strings.TrimSpace(digest)
+		res, err := client.GetTransactionBlock(ctx, digest, map[string]any{"showRawInput": true})
+		if err != nil {
+			t.Fatalf("get transaction %s failed: %v", digest, err)
+		}
+		c := chainTransaction{Digest: digest}
+		c.RawTransaction, _ = res["rawTransaction"].(string)
+		if err := checkChainTransaction(c); errors.Is(err, errNotProgrammable) {
+			t.Logf("skip %s: %v", digest, err)
+			continue
+		} else if err != nil {
+			t.Fatalf("%s: %v", digest, err)
+		}
+		checked = append(checked, c)
+	}
+	if len(checked) == 0 {
+		t.Fatalf("no programmable transaction among %d digests", len(digests))
+	}
+	if os.Getenv("SUI_IT_WRITE_GOLDENS") == "1" {
+		data, err := json.MarshalIndent(checked, "", "  ")
+		if err != nil {
+			t.Fatalf("marshal goldens failed: %v", err)
+		}
+		if err := os.WriteFile(chainTransactionsFile, append(data, '\n'), 0o644); err != nil {
+			t.Fatalf("write goldens failed: %v", err)
+		}
+	}
+}
+
 func TestPTBFullnodeIntegrationSignAndExecute(t *testing.T) {
 	cfg := loadFullnodeITConfig(t)
 	if !cfg.enableExecute {
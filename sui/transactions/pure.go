@@ -0,0 +1,234 @@
+package transactions
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/sui-sdks/go-sdks/bcs"
+)
+
+// TransactionPure adds BCS-encoded pure inputs to a transaction.
+type TransactionPure struct {
+	tx *Transaction
+}
+
+// Pure returns the typed pure-value encoders for the transaction.
+func (t *Transaction) Pure() TransactionPure { return TransactionPure{tx: t} }
+
+func (p TransactionPure) Bytes(value []byte) Argument { return p.tx.PureBytes(value) }
+
+func (p TransactionPure) U8(v uint8) Argument { return p.tx.PureBytes([]byte{v}) }
+
+func (p TransactionPure) U16(v uint16) Argument {
+	w := bcs.NewWriter(nil)
+	_ = w.Write16(v)
+	return p.tx.PureBytes(w.ToBytes())
+}
+
+func (p TransactionPure) U32(v uint32) Argument {
+	w := bcs.NewWriter(nil)
+	_ = w.Write32(v)
+	return p.tx.PureBytes(w.ToBytes())
+}
+
+func (p TransactionPure) U64(v uint64) Argument {
+	w := bcs.NewWriter(nil)
+	_ = w.Write64(v)
+	return p.tx.PureBytes(w.ToBytes())
+}
+
+func (p TransactionPure) U128(v *big.Int) (Argument, error) { return p.Value("u128", v) }
+
+func (p TransactionPure) U256(v *big.Int) (Argument, error) { return p.Value("u256", v) }
+
+func (p TransactionPure) Bool(v bool) Argument {
+	if v {
+		return p.tx.PureBytes([]byte{1})
+	}
+	return p.tx.PureBytes([]byte{0})
+}
+
+func (p TransactionPure) Address(v string) (Argument, error) { return p.Value("address", v) }
+
+func (p TransactionPure) ID(v string) (Argument, error) { return p.Value("id", v) }
+
+func (p TransactionPure) String(v string) Argument {
+	w := bcs.NewWriter(nil)
+	_ = w.WriteULEB(uint64(len(v)))
+	_ = w.WriteBytes([]byte(v))
+	return p.tx.PureBytes(w.ToBytes())
+}
+
+// Vector encodes values (any Go slice or array) as vector<elementType>.
+func (p TransactionPure) Vector(elementType string, values any) (Argument, error) {
+	return p.Value("vector<"+elementType+">", values)
+}
+
+// Option encodes value as Option<innerType>. A nil value or nil pointer is None.
+func (p TransactionPure) Option(innerType string, value any) (Argument, error) {
+	return p.Value("option<"+innerType+">", value)
+}
+
+// Value encodes value according to a pure type name such as "u64", "address",
+// "string", "vector<u8>" or "option<vector<address>>".
+func (p TransactionPure) Value(typ string, value any) (Argument, error) {
+	b, err := serializePure(typ, value)
+	if err != nil {
+		return Argument{}, err
+	}
+	return p.tx.PureBytes(b), nil
+}
+
+func serializePure(typ string, value any) ([]byte, error) {
+	schema, err := pureBCSType(typ)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := normalizePureValue(typ, value)
+	if err != nil {
+		return nil, err
+	}
+	serialized, err := schema.Serialize(normalized, nil)
+	if err != nil {
+		return nil, err
+	}
+	return serialized.ToBytes(), nil
+}
+
+func pureBCSType(typ string) (*bcs.Type, error) {
+	b := bcs.BCS
+	switch typ = strings.TrimSpace(typ); typ {
+	case "u8":
+		return b.U8(), nil
+	case "u16":
+		return b.U16(), nil
+	case "u32":
+		return b.U32(), nil
+	case "u64":
+		return b.U64(), nil
+	case "u128":
+		return b.U128(), nil
+	case "u256":
+		return b.U256(), nil
+	case "bool":
+		return b.Bool(), nil
+	case "address", "id":
+		return BCS.Address, nil
+	case "string":
+		return b.String(), nil
+	}
+	if inner, ok := pureTypeParam(typ, "vector"); ok {
+		t, err := pureBCSType(inner)
+		if err != nil {
+			return nil, err
+		}
+		return b.Vector(t), nil
+	}
+	if inner, ok := pureTypeParam(typ, "option"); ok {
+		t, err := pureBCSType(inner)
+		if err != nil {
+			return nil, err
+		}
+		return b.Option(t), nil
+	}
+	return nil, fmt.Errorf("unsupported pure type: %q", typ)
+}
+
+func pureTypeParam(typ, name string) (string, bool) {
+	typ = strings.TrimSpace(typ)
+	if !strings.HasPrefix(typ, name+"<") || !strings.HasSuffix(typ, ">") {
+		return "", false
+	}
+	return typ[len(name)+1 : len(typ)-1], true
+}
+
+// normalizePureValue converts loosely typed Go values (including numbers
+// decoded from JSON) into the shapes the bcs package serializes.
+func normalizePureValue(typ string, value any) (any, error) {
+	switch typ = strings.TrimSpace(typ); typ {
+	case "u8", "u16", "u32", "u64":
+		return toU64String(value)
+	case "u128", "u256":
+		return toBigUint(value)
+	case "bool", "address", "id", "string":
+		if !validPureScalar(typ, value) {
+			return nil, fmt.Errorf("invalid %s value: %v", typ, value)
+		}
+		return value, nil
+	}
+	if inner, ok := pureTypeParam(typ, "vector"); ok {
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("invalid %s value: %v", typ, value)
+		}
+		out := make([]any, rv.Len())
+		for i := range out {
+			v, err := normalizePureValue(inner, rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			out[i] = v
+		}
+		return out, nil
+	}
+	if inner, ok := pureTypeParam(typ, "option"); ok {
+		if value == nil {
+			return nil, nil
+		}
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return nil, nil
+			}
+			if _, isBig := value.(*big.Int); !isBig {
+				value = rv.Elem().Interface()
+			}
+		}
+		return normalizePureValue(inner, value)
+	}
+	return nil, fmt.Errorf("unsupported pure type: %q", typ)
+}
+
+func validPureScalar(typ string, value any) bool {
+	switch typ {
+	case "bool":
+		_, ok := value.(bool)
+		return ok
+	default:
+		_, ok := value.(string)
+		return ok
+	}
+}
+
+func toBigUint(v any) (*big.Int, error) {
+	var n *big.Int
+	switch t := v.(type) {
+	case *big.Int:
+		if t == nil {
+			return nil, fmt.Errorf("invalid unsigned integer: %v", v)
+		}
+		n = new(big.Int).Set(t)
+	case big.Int:
+		n = new(big.Int).Set(&t)
+	case string:
+		parsed, ok := new(big.Int).SetString(t, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid unsigned integer: %q", t)
+		}
+		n = parsed
+	case json.Number:
+		return toBigUint(t.String())
+	default:
+		s, err := toU64String(v)
+		if err != nil {
+			return nil, err
+		}
+		n, _ = new(big.Int).SetString(s, 10)
+	}
+	if n.Sign() < 0 {
+		return nil, fmt.Errorf("invalid unsigned integer: %s", n)
+	}
+	return n, nil
+}
@@ -0,0 +1,115 @@
+package transactions
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func pureInputHex(t *testing.T, tx *Transaction, arg Argument) string {
+	t.Helper()
+	input := tx.GetData().Inputs[arg.Index]
+	if input.Pure == nil {
+		t.Fatalf("input %d is not pure: %s", arg.Index, input.Kind())
+	}
+	return hex.EncodeToString(input.Pure.Bytes)
+}
+
+func TestPureScalarEncoding(t *testing.T) {
+	tx := NewTransaction()
+	maxU128, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	u128, err := tx.Pure().U128(maxU128)
+	if err != nil {
+		t.Fatalf("u128 failed: %v", err)
+	}
+	u256, err := tx.Pure().U256(big.NewInt(1))
+	if err != nil {
+		t.Fatalf("u256 failed: %v", err)
+	}
+	addr, err := tx.Pure().Address("0x2")
+	if err != nil {
+		t.Fatalf("address failed: %v", err)
+	}
+
+	cases := []struct {
+		arg  Argument
+		want string
+	}{
+		{tx.Pure().U8(7), "07"},
+		{tx.Pure().U16(0x0102), "0201"},
+		{tx.Pure().U32(1), "01000000"},
+		{tx.Pure().U64(100), "6400000000000000"},
+		{u128, "ffffffffffffffffffffffffffffffff"},
+		{u256, "01" + "00000000000000000000000000000000000000000000000000000000000000"},
+		{tx.Pure().Bool(true), "01"},
+		{addr, "0000000000000000000000000000000000000000000000000000000000000002"},
+		{tx.Pure().String("sui"), "03737569"},
+	}
+	for i, c := range cases {
+		if got := pureInputHex(t, tx, c.arg); got != c.want {
+			t.Fatalf("case %d: got %s want %s", i, got, c.want)
+		}
+	}
+}
+
+func TestPureVectorAndOptionEncoding(t *testing.T) {
+	tx := NewTransaction()
+	five := uint8(5)
+	var none *uint64
+	cases := []struct {
+		typ   string
+		value any
+		want  string
+	}{
+		{"vector<u64>", []uint64{1, 2}, "02" + "0100000000000000" + "0200000000000000"},
+		{"vector<u8>", []byte{0xaa, 0xbb}, "02aabb"},
+		{"vector<u128>", []string{"1"}, "01" + "01000000000000000000000000000000"},
+		{"vector<string>", []any{"a", "bc"}, "020161026263"},
+		{"vector<vector<bool>>", [][]bool{{true}, {}}, "02" + "0101" + "00"},
+		{"option<u8>", &five, "0105"},
+		{"option<u64>", none, "00"},
+		{"option<u32>", nil, "00"},
+		{"option<vector<u16>>", []float64{3}, "01" + "01" + "0300"},
+	}
+	for _, c := range cases {
+		arg, err := tx.Pure().Value(c.typ, c.value)
+		if err != nil {
+			t.Fatalf("%s: encode failed: %v", c.typ, err)
+		}
+		if got := pureInputHex(t, tx, arg); got != c.want {
+			t.Fatalf("%s: got %s want %s", c.typ, got, c.want)
+		}
+	}
+
+	if _, err := tx.Pure().Vector("u64", []int{1}); err != nil {
+		t.Fatalf("vector helper failed: %v", err)
+	}
+	if _, err := tx.Pure().Option("address", "0x6"); err != nil {
+		t.Fatalf("option helper failed: %v", err)
+	}
+}
+
+func TestPureRejectsInvalidValues(t *testing.T) {
+	tx := NewTransaction()
+	cases := []struct {
+		typ   string
+		value any
+	}{
+		{"u8", 256},
+		{"u64", -1},
+		{"u64", 1.5},
+		{"u128", new(big.Int).Lsh(big.NewInt(1), 128)},
+		{"bool", 1},
+		{"address", "not-an-address"},
+		{"vector<u8>", "abc"},
+		{"0x2::coin::Coin<0x2::sui::SUI>", "0x1"},
+	}
+	for _, c := range cases {
+		if _, err := tx.Pure().Value(c.typ, c.value); err == nil {
+			t.Fatalf("%s: expected error for %v", c.typ, c.value)
+		}
+	}
+	if n := len(tx.GetData().Inputs); n != 0 {
+		t.Fatalf("failed encodings must not add inputs, got %d", n)
+	}
+}
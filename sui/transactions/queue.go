@@ -1,6 +1,10 @@
 package transactions
 
-import "sync"
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
 
 type SerialQueue struct {
 	mu      sync.Mutex
@@ -9,8 +13,28 @@ type SerialQueue struct {
 }
 
 func (q *SerialQueue) RunTask(task func() error) error {
+	return q.RunTaskContext(context.Background(), task)
+}
+
+// RunTaskContext queues task behind the tasks already queued. If ctx is done
+// before the task starts, the task is dropped and ctx's error returned; a
+// task that has started always runs to completion.
+func (q *SerialQueue) RunTaskContext(ctx context.Context, task func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	const (
+		queued = iota
+		started
+		dropped
+	)
+	var state atomic.Int32
 	ch := make(chan error, 1)
-	wrapped := func() { ch <- task() }
+	wrapped := func() {
+		if state.CompareAndSwap(queued, started) {
+			ch <- task()
+		}
+	}
 	q.mu.Lock()
 	q.queue = append(q.queue, wrapped)
 	if !q.running {
@@ -18,7 +42,15 @@ func (q *SerialQueue) RunTask(task func() error) error {
 		go q.drain()
 	}
 	q.mu.Unlock()
-	return <-ch
+	select {
+	case err := <-ch:
+		return err
+	case <-ctx.Done():
+		if state.CompareAndSwap(queued, dropped) {
+			return ctx.Err()
+		}
+		return <-ch
+	}
 }
 
 func (q *SerialQueue) drain() {
@@ -48,7 +80,20 @@ func NewParallelQueue(maxTasks int) *ParallelQueue {
 }
 
 func (q *ParallelQueue) RunTask(task func() error) error {
-	q.sem <- struct{}{}
+	return q.RunTaskContext(context.Background(), task)
+}
+
+// RunTaskContext runs task once fewer than the maximum number of tasks are
+// running, or returns ctx's error if ctx is done first.
+func (q *ParallelQueue) RunTaskContext(ctx context.Context, task func() error) error {
+	select {
+	case q.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 	defer func() { <-q.sem }()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	return task()
 }
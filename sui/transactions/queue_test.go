@@ -0,0 +1,84 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSerialQueueDropsCancelledTasks(t *testing.T) {
+	var q SerialQueue
+	release := make(chan struct{})
+	first := make(chan error, 1)
+	go func() {
+		first <- q.RunTask(func() error {
+			<-release
+			return nil
+		})
+	}()
+	for {
+		q.mu.Lock()
+		running := q.running
+		q.mu.Unlock()
+		if running {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := false
+	queued := make(chan error, 1)
+	go func() {
+		queued <- q.RunTaskContext(ctx, func() error {
+			ran = true
+			return nil
+		})
+	}()
+	cancel()
+	if err := <-queued; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancelled task to return context.Canceled, got %v", err)
+	}
+	close(release)
+	if err := <-first; err != nil {
+		t.Fatalf("running task failed: %v", err)
+	}
+	if err := q.RunTask(func() error { return nil }); err != nil {
+		t.Fatalf("queue did not recover: %v", err)
+	}
+	if ran {
+		t.Fatalf("cancelled task must not run")
+	}
+}
+
+func TestSerialQueueFinishesStartedTasks(t *testing.T) {
+	var q SerialQueue
+	ctx, cancel := context.WithCancel(context.Background())
+	err := q.RunTaskContext(ctx, func() error {
+		cancel()
+		time.Sleep(5 * time.Millisecond)
+		return errors.New("task result")
+	})
+	if err == nil || err.Error() != "task result" {
+		t.Fatalf("expected the started task's own result, got %v", err)
+	}
+}
+
+func TestParallelQueueHonorsContext(t *testing.T) {
+	q := NewParallelQueue(1)
+	release := make(chan struct{})
+	go q.RunTask(func() error {
+		<-release
+		return nil
+	})
+	for len(q.sem) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := q.RunTaskContext(ctx, func() error { return nil }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error waiting for a slot, got %v", err)
+	}
+	close(release)
+}
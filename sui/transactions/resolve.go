@@ -3,11 +3,33 @@ package transactions
 import (
 	"context"
 	"errors"
+	"fmt"
 )
 
 type BuildTransactionOptions struct {
 	Client           CoreClient
 	OnlyTransactionKind bool
+	// Limits are the protocol limits the transaction is checked against. When
+	// nil, DefaultProtocolLimits are used unless FetchLimits is set.
+	Limits *ProtocolLimits
+	// FetchLimits fetches the limits from Client with sui_getProtocolConfig on
+	// every build that has no Limits. Callers building many transactions
+	// should fetch them once with FetchProtocolLimits and pass Limits instead.
+	FetchLimits bool
+	// intentResolvers is set by Transaction.Build to the global resolvers
+	// merged with the transaction's own.
+	intentResolvers map[string]TransactionPlugin
+	// ctx is set by Transaction.BuildContext.
+	ctx context.Context
+}
+
+// Context returns the context the build runs under. Plugins should pass it to
+// any client calls they make.
+func (o BuildTransactionOptions) Context() context.Context {
+	if o.ctx == nil {
+		return context.Background()
+	}
+	return o.ctx
 }
 
 type SerializeTransactionOptions struct {
@@ -22,8 +44,11 @@ type CoreClient interface {
 }
 
 func NeedsTransactionResolution(data *TransactionData, options BuildTransactionOptions) bool {
+	if hasIntents(data) {
+		return true
+	}
 	for _, input := range data.Inputs {
-		if input["$kind"] == "UnresolvedObject" || input["$kind"] == "UnresolvedPure" {
+		if input.UnresolvedObject != nil || input.UnresolvedPure != nil {
 			return true
 		}
 	}
@@ -39,7 +64,16 @@ func NeedsTransactionResolution(data *TransactionData, options BuildTransactionO
 }
 
 func ResolveTransactionPlugin(transactionData *TransactionData, options BuildTransactionOptions, next func() error) error {
-	normalizeRawArguments(transactionData)
+	resolvers := options.intentResolvers
+	if resolvers == nil {
+		resolvers = globalIntentResolvers()
+	}
+	if err := resolveIntents(transactionData, options, resolvers); err != nil {
+		return err
+	}
+	if err := normalizeRawArguments(transactionData); err != nil {
+		return err
+	}
 	if !NeedsTransactionResolution(transactionData, options) {
 		if err := validateResolvedInputs(transactionData); err != nil {
 			return err
@@ -60,47 +94,48 @@ func ResolveTransactionPlugin(transactionData *TransactionData, options BuildTra
 
 func validateResolvedInputs(transactionData *TransactionData) error {
 	for i, input := range transactionData.Inputs {
-		kind, _ := input["$kind"].(string)
-		if kind != "Object" && kind != "Pure" {
+		if input.Object == nil && input.Pure == nil {
 			return errors.New("input at index " + itoa(i) + " has not been resolved")
 		}
 	}
 	return nil
 }
 
-func normalizeRawArguments(transactionData *TransactionData) {
+// normalizeRawArguments encodes UnresolvedPure inputs whose type is fixed by
+// the command itself: TransferObjects recipients and SplitCoins amounts.
+func normalizeRawArguments(transactionData *TransactionData) error {
 	for _, cmd := range transactionData.Commands {
-		kind, _ := cmd["$kind"].(string)
-		switch kind {
-		case "TransferObjects":
-			payload, _ := cmd["TransferObjects"].(map[string]any)
-			if addrArg, ok := payload["address"].(map[string]any); ok {
-				normalizeRawArgument(addrArg, transactionData)
+		switch {
+		case cmd.TransferObjects != nil:
+			if err := normalizeRawArgument(cmd.TransferObjects.Address, "address", transactionData); err != nil {
+				return err
 			}
-		case "SplitCoins":
-			payload, _ := cmd["SplitCoins"].(map[string]any)
-			if amounts, ok := payload["amounts"].([]Argument); ok {
-				for _, a := range amounts {
-					normalizeRawArgument(a, transactionData)
+		case cmd.SplitCoins != nil:
+			for _, a := range cmd.SplitCoins.Amounts {
+				if err := normalizeRawArgument(a, "u64", transactionData); err != nil {
+					return err
 				}
 			}
 		}
 	}
+	return nil
 }
 
-func normalizeRawArgument(arg Argument, transactionData *TransactionData) {
-	if arg["$kind"] != "Input" {
-		return
+func normalizeRawArgument(arg Argument, typ string, transactionData *TransactionData) error {
+	if arg.Kind != ArgumentInput || int(arg.Index) >= len(transactionData.Inputs) {
+		return nil
 	}
-	idx, ok := arg["Input"].(int)
-	if !ok || idx < 0 || idx >= len(transactionData.Inputs) {
-		return
+	input := transactionData.Inputs[arg.Index]
+	if input.UnresolvedPure == nil {
+		return nil
 	}
-	input := transactionData.Inputs[idx]
-	if input["$kind"] != "UnresolvedPure" {
-		return
+	b, ok := input.UnresolvedPure.Value.([]byte)
+	if !ok {
+		var err error
+		if b, err = serializePure(typ, input.UnresolvedPure.Value); err != nil {
+			return fmt.Errorf("invalid %s value for input %d: %w", typ, arg.Index, err)
+		}
 	}
-	value := input["UnresolvedPure"]
-	// baseline fallback: encode as text bytes.
-	transactionData.Inputs[idx] = Inputs.Pure([]byte(toString(value)))
+	transactionData.Inputs[arg.Index] = Inputs.Pure(b)
+	return nil
 }
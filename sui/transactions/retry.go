@@ -0,0 +1,355 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sui-sdks/go-sdks/sui/jsonrpc"
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+// ExecutionErrorKind classifies why executing a transaction failed.
+type ExecutionErrorKind string
+
+const (
+	ExecutionErrorUnknown ExecutionErrorKind = "Unknown"
+	// ExecutionErrorStaleObject means an input object was not at the version
+	// the transaction referenced, usually because the cache was out of date.
+	ExecutionErrorStaleObject ExecutionErrorKind = "StaleObject"
+	// ExecutionErrorObjectLocked means validators have locked an input object
+	// for another transaction, possibly until the end of the epoch.
+	ExecutionErrorObjectLocked ExecutionErrorKind = "ObjectLocked"
+	// ExecutionErrorInsufficientGas means the gas budget or the gas coin
+	// balance did not cover the transaction.
+	ExecutionErrorInsufficientGas ExecutionErrorKind = "InsufficientGas"
+	// ExecutionErrorTimeout means the request did not get an answer, so the
+	// transaction may or may not have been executed.
+	ExecutionErrorTimeout ExecutionErrorKind = "Timeout"
+)
+
+var executionErrorPatterns = []struct {
+	kind     ExecutionErrorKind
+	patterns []string
+}{
+	{ExecutionErrorObjectLocked, []string{"equivocated", "objectlockconflict", "already locked", "reserved for another transaction"}},
+	{ExecutionErrorStaleObject, []string{"not available for consumption", "objectversionunavailableforconsumption", "objectnotfound", "could not find the referenced object"}},
+	{ExecutionErrorInsufficientGas, []string{"insufficientgas", "gasbalancetoolow", "lower than the needed amount", "insufficient gas"}},
+	{ExecutionErrorTimeout, []string{"timed out", "timeout", "deadline exceeded"}},
+}
+
+// ClassifyExecutionError reports the kind of a transaction execution error,
+// whether returned by the client or reported in the effects of an executed
+// transaction as an *ExecutionFailedError.
+func ClassifyExecutionError(err error) ExecutionErrorKind {
+	if err == nil {
+		return ""
+	}
+	if errors.Is(err, context.Canceled) {
+		return ExecutionErrorUnknown
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ExecutionErrorTimeout
+	}
+	var timeout interface{ Timeout() bool }
+	if errors.As(err, &timeout) && timeout.Timeout() {
+		return ExecutionErrorTimeout
+	}
+	var status *jsonrpc.HTTPStatusError
+	if errors.As(err, &status) {
+		switch status.StatusCode {
+		case http.StatusRequestTimeout, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return ExecutionErrorTimeout
+		}
+	}
+	msg := strings.ToLower(err.Error())
+	for _, class := range executionErrorPatterns {
+		for _, p := range class.patterns {
+			if strings.Contains(msg, p) {
+				return class.kind
+			}
+		}
+	}
+	return ExecutionErrorUnknown
+}
+
+// ExecutionFailedError describes a transaction that was executed but failed,
+// as reported by the status in its effects.
+type ExecutionFailedError struct {
+	Digest string
+	Status string
+}
+
+func (e *ExecutionFailedError) Error() string {
+	return fmt.Sprintf("transaction %s failed: %s", e.Digest, e.Status)
+}
+
+// executionFailure returns the failure reported in the effects of res, if any.
+func executionFailure(res map[string]any) error {
+	effects, _ := res["effects"].(map[string]any)
+	status, _ := effects["status"].(map[string]any)
+	if toString(status["status"]) != "failure" {
+		return nil
+	}
+	return &ExecutionFailedError{Digest: toString(res["digest"]), Status: toString(status["error"])}
+}
+
+var objectIDPattern = regexp.MustCompile(`0x[0-9a-fA-F]{64}`)
+
+// affectedObjects returns the object IDs mentioned in an execution error.
+func affectedObjects(err error) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, id := range objectIDPattern.FindAllString(err.Error(), -1) {
+		id = utils.NormalizeSuiObjectID(id)
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// pinsObjects reports whether tx references any of ids at a fixed version,
+// in which case rebuilding it cannot pick up a newer one.
+func pinsObjects(tx *Transaction, ids []string) bool {
+	want := map[string]bool{}
+	for _, id := range ids {
+		want[id] = true
+	}
+	for _, ref := range tx.data.GasData.Payment {
+		if want[utils.NormalizeSuiObjectID(ref.ObjectID)] {
+			return true
+		}
+	}
+	for _, input := range tx.data.Inputs {
+		var id string
+		switch {
+		case input.Object != nil && input.Object.ImmOrOwnedObject != nil:
+			id = input.Object.ImmOrOwnedObject.ObjectID
+		case input.Object != nil && input.Object.Receiving != nil:
+			id = input.Object.Receiving.ObjectID
+		case input.UnresolvedObject != nil && input.UnresolvedObject.Version != nil:
+			id = input.UnresolvedObject.ObjectID
+		default:
+			continue
+		}
+		if want[utils.NormalizeSuiObjectID(id)] {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryPolicy configures how the serial and parallel executors retry failed
+// transactions. Only failures that are safe to retry are retried:
+//
+//   - stale object versions are retried by rebuilding against fresh objects,
+//     unless the transaction pins the stale object to a version;
+//   - insufficient gas is retried by rebuilding with another gas coin or,
+//     when execution ran out of gas, with double the budget, unless the
+//     transaction sets its own budget;
+//   - timeouts are retried by looking the transaction up by digest and
+//     resubmitting the same signed bytes if it was not executed;
+//   - locked or equivocated objects are never retried.
+//
+// Transactions passed as bytes can only be retried after timeouts.
+type RetryPolicy struct {
+	// MaxAttempts is the most times a transaction is attempted, including
+	// the first. Defaults to 3.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry. Defaults to 200ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts. Defaults to 5s.
+	MaxBackoff time.Duration
+	// Multiplier grows the wait after every retry. Defaults to 2.
+	Multiplier float64
+	// MaxGasBudget caps the budget of retries after running out of gas.
+	// Defaults to MaxGas.
+	MaxGasBudget int64
+	// OnAttempt, when set, is called with the outcome of every attempt.
+	OnAttempt func(RetryEvent)
+}
+
+// RetryEvent is the outcome of one attempt at executing a transaction.
+type RetryEvent struct {
+	Attempt int
+	// Kind is empty when the attempt succeeded.
+	Kind ExecutionErrorKind
+	// Err is the error of the attempt, or an *ExecutionFailedError when the
+	// transaction was executed but failed.
+	Err    error
+	Digest string
+	// Retry reports whether another attempt follows, after Backoff.
+	Retry   bool
+	Backoff time.Duration
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts <= 0 {
+		return 3
+	}
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) maxGasBudget() int64 {
+	if p.MaxGasBudget <= 0 {
+		return MaxGas
+	}
+	return p.MaxGasBudget
+}
+
+// backoff returns the wait after the given attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	initial, max, multiplier := p.InitialBackoff, p.MaxBackoff, p.Multiplier
+	if initial <= 0 {
+		initial = 200 * time.Millisecond
+	}
+	if max <= 0 {
+		max = 5 * time.Second
+	}
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	d := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if d > float64(max) {
+		return max
+	}
+	return time.Duration(d)
+}
+
+// retrier counts the attempts at executing one transaction. A nil policy
+// never retries.
+type retrier struct {
+	policy  *RetryPolicy
+	attempt int
+	// done is set once the outcome of the last attempt has been reported.
+	done bool
+}
+
+// next reports the outcome of the current attempt and, when retry is true
+// and attempts remain, waits out the backoff. It returns whether to try
+// again.
+func (r *retrier) next(ctx context.Context, event RetryEvent, retry bool) bool {
+	r.attempt++
+	if r.policy == nil {
+		return false
+	}
+	event.Attempt = r.attempt
+	event.Retry = retry && r.attempt < r.policy.maxAttempts() && ctx.Err() == nil
+	if event.Retry {
+		event.Backoff = r.policy.backoff(r.attempt)
+	}
+	if r.policy.OnAttempt != nil {
+		r.policy.OnAttempt(event)
+	}
+	if !event.Retry {
+		return false
+	}
+	timer := time.NewTimer(event.Backoff)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// finish reports the final outcome of an attempt that is not retried.
+func (r *retrier) finish(ctx context.Context, bytes []byte, res map[string]any, err error) {
+	event := RetryEvent{Err: err}
+	if err == nil {
+		event.Err = executionFailure(res)
+	}
+	event.Kind = ClassifyExecutionError(event.Err)
+	if bytes != nil {
+		event.Digest = TransactionDigestFromBytes(bytes)
+	}
+	r.next(ctx, event, false)
+}
+
+// rebuild decides whether a failed attempt at executing tx can be retried by
+// building it again. budget is the gas budget the attempt used; the returned
+// budget is the one the retry should use.
+func (r *retrier) rebuild(ctx context.Context, tx *Transaction, bytes []byte, res map[string]any, err error, budget int64) (int64, bool) {
+	failure := err
+	if failure == nil {
+		failure = executionFailure(res)
+	}
+	if r.done {
+		return budget, false
+	}
+	event := RetryEvent{Err: failure, Kind: ClassifyExecutionError(failure)}
+	if bytes != nil {
+		event.Digest = TransactionDigestFromBytes(bytes)
+	}
+	retry := false
+	if r.policy != nil && tx != nil {
+		switch event.Kind {
+		case ExecutionErrorTimeout:
+			// Timeouts while submitting are handled by submit, so this one
+			// happened while building and nothing was executed.
+			retry = true
+		case ExecutionErrorStaleObject:
+			retry = !pinsObjects(tx, affectedObjects(failure))
+		case ExecutionErrorInsufficientGas:
+			switch {
+			case err != nil:
+				// Rejected before execution: the gas coin was too small and
+				// has been dropped, so a rebuild picks another.
+				retry = true
+			case tx.data.GasData.Budget == "" && budget < r.policy.maxGasBudget():
+				budget = min(budget*2, r.policy.maxGasBudget())
+				retry = true
+			}
+		}
+	}
+	return budget, r.next(ctx, event, retry)
+}
+
+// submit executes signed transaction bytes. After a timeout, when the policy
+// allows another attempt, it looks the transaction up by digest and
+// resubmits the same bytes only if it was not executed.
+func (e *CachingTransactionExecutor) submit(ctx context.Context, r *retrier, opts ExecuteTransactionOptions) (map[string]any, error) {
+	digest := TransactionDigestFromBytes(opts.Transaction)
+	for {
+		res, err := e.ExecuteTransactionContext(ctx, opts)
+		if err == nil || ClassifyExecutionError(err) != ExecutionErrorTimeout {
+			return res, err
+		}
+		if !r.next(ctx, RetryEvent{Kind: ExecutionErrorTimeout, Err: err, Digest: digest}, true) {
+			r.done = true
+			return nil, err
+		}
+		if res, ok := e.findTransaction(ctx, digest, opts.Include); ok {
+			return res, nil
+		}
+	}
+}
+
+// findTransaction returns an executed transaction by digest, applying its
+// effects to the cache.
+func (e *CachingTransactionExecutor) findTransaction(ctx context.Context, digest string, include map[string]any) (map[string]any, bool) {
+	options := map[string]any{}
+	for k, v := range include {
+		options[k] = v
+	}
+	options["showEffects"] = true
+	var out map[string]any
+	if err := e.client.Call(ctx, "sui_getTransactionBlock", []any{digest, options}, &out); err != nil || toString(out["digest"]) != digest {
+		return nil, false
+	}
+	if effects, ok := out["effects"].(map[string]any); ok {
+		e.ApplyEffects(effects)
+	}
+	e.mu.Lock()
+	e.lastDigest = digest
+	e.mu.Unlock()
+	return out, true
+}
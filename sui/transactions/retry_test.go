@@ -0,0 +1,261 @@
+package transactions
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sui-sdks/go-sdks/sui/jsonrpc"
+	edkp "github.com/sui-sdks/go-sdks/sui/keypairs/ed25519"
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "i/o timeout" }
+func (timeoutError) Timeout() bool { return true }
+
+func TestClassifyExecutionError(t *testing.T) {
+	id := utils.NormalizeSuiObjectID("0xa")
+	cases := []struct {
+		err  error
+		want ExecutionErrorKind
+	}{
+		{nil, ""},
+		{fmt.Errorf("Object ID %s Version 0x3 Digest abc is not available for consumption, current version: 0x4", id), ExecutionErrorStaleObject},
+		{errors.New("Failed to sign transaction by a quorum of validators because one or more of its objects is equivocated until the next epoch"), ExecutionErrorObjectLocked},
+		{&ExecutionFailedError{Digest: "abc", Status: "InsufficientGas"}, ExecutionErrorInsufficientGas},
+		{errors.New("Balance of gas object 10 is lower than the needed amount: 100"), ExecutionErrorInsufficientGas},
+		{&jsonrpc.HTTPTransportError{Cause: timeoutError{}}, ExecutionErrorTimeout},
+		{&jsonrpc.HTTPStatusError{StatusCode: 504}, ExecutionErrorTimeout},
+		{context.DeadlineExceeded, ExecutionErrorTimeout},
+		{context.Canceled, ExecutionErrorUnknown},
+		{errors.New("invalid signature"), ExecutionErrorUnknown},
+	}
+	for _, c := range cases {
+		if got := ClassifyExecutionError(c.err); got != c.want {
+			t.Fatalf("ClassifyExecutionError(%v) = %q, want %q", c.err, got, c.want)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	for attempt, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond} {
+		if got := p.backoff(attempt + 1); got != want {
+			t.Fatalf("backoff(%d) = %v, want %v", attempt+1, got, want)
+		}
+	}
+}
+
+// scriptedCore fails executions with scripted errors or effects, in order,
+// before executing normally.
+type scriptedCore struct {
+	lockingCore
+	mu       sync.Mutex
+	errs     []error
+	failures []string
+	// found, when set, makes sui_getTransactionBlock find every executed
+	// transaction.
+	found    bool
+	executed []string
+	budgets  []string
+	lookups  int
+}
+
+func (c *scriptedCore) Call(ctx context.Context, method string, params []any, out any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch method {
+	case "sui_executeTransactionBlock":
+		txB64 := params[0].(string)
+		c.executed = append(c.executed, txB64)
+		raw, _ := base64.StdEncoding.DecodeString(txB64)
+		if tx, err := TransactionFromBytes(raw); err == nil {
+			c.budgets = append(c.budgets, tx.GetData().GasData.Budget)
+		}
+		if len(c.errs) > 0 {
+			err := c.errs[0]
+			c.errs = c.errs[1:]
+			if err != nil {
+				return err
+			}
+		}
+		if len(c.failures) > 0 {
+			effects := mockEffects(txB64)
+			effects["status"] = map[string]any{"status": "failure", "error": c.failures[0]}
+			c.failures = c.failures[1:]
+			*out.(*map[string]any) = map[string]any{"digest": "failed", "effects": effects}
+			return nil
+		}
+	case "sui_getTransactionBlock":
+		c.lookups++
+		if c.found && len(c.executed) > 0 {
+			raw, _ := base64.StdEncoding.DecodeString(c.executed[len(c.executed)-1])
+			*out.(*map[string]any) = map[string]any{"digest": TransactionDigestFromBytes(raw), "effects": mockEffects(c.executed[len(c.executed)-1])}
+			return nil
+		}
+	}
+	return c.lockingCore.Call(ctx, method, params, out)
+}
+
+func newScriptedCore(errs ...error) *scriptedCore {
+	return &scriptedCore{lockingCore: lockingCore{active: map[string]int{}}, errs: errs}
+}
+
+func newRetryingSerialExecutor(t *testing.T, client ExecuteCore, events *[]RetryEvent) *SerialTransactionExecutor {
+	t.Helper()
+	signer, err := edkp.Generate()
+	if err != nil {
+		t.Fatalf("generate signer failed: %v", err)
+	}
+	return NewSerialTransactionExecutor(SerialTransactionExecutorOptions{
+		Client: client,
+		Signer: signer,
+		Retry: &RetryPolicy{
+			InitialBackoff: time.Millisecond,
+			OnAttempt:      func(e RetryEvent) { *events = append(*events, e) },
+		},
+	})
+}
+
+func staleObjectError(id string) error {
+	return &jsonrpc.JsonRPCError{Code: -32002, Message: fmt.Sprintf("Object ID %s Version 0x1 Digest abc is not available for consumption, current version: 0x2", utils.NormalizeSuiObjectID(id))}
+}
+
+func TestSerialExecutorRetriesStaleObjects(t *testing.T) {
+	client := newScriptedCore(staleObjectError("0xa"))
+	var events []RetryEvent
+	exec := newRetryingSerialExecutor(t, client, &events)
+
+	if _, err := exec.ExecuteTransaction(useObjects("0xa"), nil, nil); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	if len(client.executed) != 2 {
+		t.Fatalf("expected the transaction to be rebuilt and executed again, got %d executions", len(client.executed))
+	}
+	if len(events) != 2 || events[0].Kind != ExecutionErrorStaleObject || !events[0].Retry || events[1].Kind != "" || events[1].Retry {
+		t.Fatalf("unexpected retry events: %+v", events)
+	}
+}
+
+func TestSerialExecutorDoesNotRetryPinnedObjects(t *testing.T) {
+	client := newScriptedCore(staleObjectError("0xa"))
+	var events []RetryEvent
+	exec := newRetryingSerialExecutor(t, client, &events)
+
+	tx := NewTransaction()
+	tx.TransferObjects([]Argument{tx.Object(Inputs.ObjectRef(ObjectRef{ObjectID: "0xa", Version: "1", Digest: testDigest}))}, recipient(t, tx))
+	if _, err := exec.ExecuteTransaction(tx, nil, nil); ClassifyExecutionError(err) != ExecutionErrorStaleObject {
+		t.Fatalf("expected the stale object error, got %v", err)
+	}
+	if len(client.executed) != 1 || len(events) != 1 || events[0].Retry {
+		t.Fatalf("a pinned object version must not be retried, got %d executions and events %+v", len(client.executed), events)
+	}
+}
+
+func TestSerialExecutorDoesNotRetryLockedObjects(t *testing.T) {
+	client := newScriptedCore(errors.New("objects are equivocated until the next epoch"))
+	var events []RetryEvent
+	exec := newRetryingSerialExecutor(t, client, &events)
+
+	if _, err := exec.ExecuteTransaction(useObjects("0xa"), nil, nil); err == nil {
+		t.Fatalf("expected the equivocation error")
+	}
+	if len(client.executed) != 1 || len(events) != 1 || events[0].Kind != ExecutionErrorObjectLocked || events[0].Retry {
+		t.Fatalf("locked objects must not be retried, got %d executions and events %+v", len(client.executed), events)
+	}
+}
+
+func TestSerialExecutorRaisesBudgetAfterInsufficientGas(t *testing.T) {
+	client := newScriptedCore()
+	client.failures = []string{"InsufficientGas"}
+	var events []RetryEvent
+	exec := newRetryingSerialExecutor(t, client, &events)
+
+	res, err := exec.ExecuteTransaction(useObjects("0xa"), nil, nil)
+	if err != nil || executionFailure(res) != nil {
+		t.Fatalf("expected the retry to succeed, got %v (%v)", err, res)
+	}
+	if len(client.budgets) != 2 || client.budgets[0] != "50000000" || client.budgets[1] != "100000000" {
+		t.Fatalf("expected the budget to double on retry, got %v", client.budgets)
+	}
+
+	// A budget set on the transaction is the caller's choice.
+	client.failures = []string{"InsufficientGas"}
+	tx := useObjects("0xa")
+	tx.SetGasBudget(1000)
+	res, err = exec.ExecuteTransaction(tx, nil, nil)
+	if err != nil || executionFailure(res) == nil || len(client.budgets) != 3 {
+		t.Fatalf("expected the failed effects without a retry, got %v after %d executions", err, len(client.budgets))
+	}
+}
+
+func TestSerialExecutorChecksTimedOutTransactions(t *testing.T) {
+	client := newScriptedCore(&jsonrpc.HTTPTransportError{Cause: timeoutError{}})
+	client.found = true
+	var events []RetryEvent
+	exec := newRetryingSerialExecutor(t, client, &events)
+	if _, err := exec.ExecuteTransaction(useObjects("0xa"), nil, nil); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	if len(client.executed) != 1 || client.lookups == 0 {
+		t.Fatalf("an executed transaction must not be resubmitted, got %d executions", len(client.executed))
+	}
+
+	client.errs = []error{&jsonrpc.HTTPTransportError{Cause: timeoutError{}}}
+	client.found = false
+	client.executed = nil
+	if _, err := exec.ExecuteTransaction(useObjects("0xa"), nil, nil); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	if len(client.executed) != 2 || client.executed[0] != client.executed[1] {
+		t.Fatalf("expected the same bytes to be resubmitted, got %d executions", len(client.executed))
+	}
+}
+
+func TestSerialExecutorStopsAfterMaxAttempts(t *testing.T) {
+	client := newScriptedCore(staleObjectError("0xa"), staleObjectError("0xa"), staleObjectError("0xa"))
+	var events []RetryEvent
+	exec := newRetryingSerialExecutor(t, client, &events)
+	exec.retry.MaxAttempts = 2
+
+	if _, err := exec.ExecuteTransaction(useObjects("0xa"), nil, nil); !strings.Contains(fmt.Sprint(err), "not available for consumption") {
+		t.Fatalf("expected the stale object error, got %v", err)
+	}
+	if len(client.executed) != 2 || len(events) != 2 || events[1].Retry {
+		t.Fatalf("expected 2 attempts, got %d executions and events %+v", len(client.executed), events)
+	}
+}
+
+func TestParallelExecutorRetriesStaleObjects(t *testing.T) {
+	client := newScriptedCore()
+	signer, _ := edkp.Generate()
+	var events []RetryEvent
+	exec := NewParallelTransactionExecutor(ParallelTransactionExecutorOptions{
+		Client:        client,
+		Signer:        signer,
+		CoinBatchSize: 2,
+		Retry: &RetryPolicy{
+			InitialBackoff: time.Millisecond,
+			OnAttempt:      func(e RetryEvent) { events = append(events, e) },
+		},
+	})
+	// Fail the first user transaction; the pool refill executes first.
+	client.errs = []error{nil, staleObjectError("0xa")}
+
+	if _, err := exec.ExecuteTransaction(useObjects("0xa"), nil, nil); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	if len(client.executed) != 3 {
+		t.Fatalf("expected a refill and two attempts, got %d executions", len(client.executed))
+	}
+	if len(events) != 2 || events[0].Kind != ExecutionErrorStaleObject || !events[0].Retry || events[1].Kind != "" {
+		t.Fatalf("unexpected retry events: %+v", events)
+	}
+}
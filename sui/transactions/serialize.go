@@ -0,0 +1,566 @@
+package transactions
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+func transactionDataToBCS(data *TransactionData) (map[string]any, error) {
+	kind, err := transactionKindToBCS(data)
+	if err != nil {
+		return nil, err
+	}
+	owner := data.GasData.Owner
+	if owner == "" {
+		owner = data.Sender
+	}
+	price, err := toU64String(data.GasData.Price)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas price: %w", err)
+	}
+	budget, err := toU64String(data.GasData.Budget)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas budget: %w", err)
+	}
+	payment := make([]any, len(data.GasData.Payment))
+	for i, ref := range data.GasData.Payment {
+		v, err := objectRefToBCS(ref)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas payment at index %d: %w", i, err)
+		}
+		payment[i] = v
+	}
+	expiration, err := expirationToBCS(data.Expiration)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"$kind": "V1",
+		"V1": map[string]any{
+			"kind":   kind,
+			"sender": data.Sender,
+			"gasData": map[string]any{
+				"payment": payment,
+				"owner":   owner,
+				"price":   price,
+				"budget":  budget,
+			},
+			"expiration": expiration,
+		},
+	}, nil
+}
+
+func transactionKindToBCS(data *TransactionData) (map[string]any, error) {
+	inputs := make([]any, len(data.Inputs))
+	for i, input := range data.Inputs {
+		v, err := callArgToBCS(input)
+		if err != nil {
+			return nil, fmt.Errorf("input at index %d: %w", i, err)
+		}
+		inputs[i] = v
+	}
+	commands := make([]any, len(data.Commands))
+	for i, cmd := range data.Commands {
+		v, err := commandToBCS(cmd)
+		if err != nil {
+			return nil, fmt.Errorf("command at index %d: %w", i, err)
+		}
+		commands[i] = v
+	}
+	return map[string]any{
+		"$kind": "ProgrammableTransaction",
+		"ProgrammableTransaction": map[string]any{
+			"inputs":   inputs,
+			"commands": commands,
+		},
+	}, nil
+}
+
+func callArgToBCS(arg CallArg) (map[string]any, error) {
+	switch arg.Kind() {
+	case "Pure":
+		return map[string]any{"$kind": "Pure", "Pure": map[string]any{"bytes": arg.Pure.Bytes}}, nil
+	case "Object":
+		obj := arg.Object
+		var value map[string]any
+		switch obj.Kind() {
+		case "ImmOrOwnedObject":
+			ref, err := objectRefToBCS(*obj.ImmOrOwnedObject)
+			if err != nil {
+				return nil, err
+			}
+			value = map[string]any{"$kind": "ImmOrOwnedObject", "ImmOrOwnedObject": ref}
+		case "Receiving":
+			ref, err := objectRefToBCS(*obj.Receiving)
+			if err != nil {
+				return nil, err
+			}
+			value = map[string]any{"$kind": "Receiving", "Receiving": ref}
+		case "SharedObject":
+			shared := obj.SharedObject
+			version, err := toU64String(shared.InitialSharedVersion)
+			if err != nil {
+				return nil, fmt.Errorf("invalid initial shared version: %w", err)
+			}
+			value = map[string]any{"$kind": "SharedObject", "SharedObject": map[string]any{
+				"objectId":             shared.ObjectID,
+				"initialSharedVersion": version,
+				"mutable":              shared.Mutable,
+			}}
+		default:
+			return nil, errors.New("empty object input")
+		}
+		return map[string]any{"$kind": "Object", "Object": value}, nil
+	default:
+		return nil, fmt.Errorf("input of kind %q has not been resolved", arg.Kind())
+	}
+}
+
+func objectRefToBCS(ref ObjectRef) (map[string]any, error) {
+	version, err := toU64String(ref.Version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object version: %w", err)
+	}
+	return map[string]any{"objectId": ref.ObjectID, "version": version, "digest": ref.Digest}, nil
+}
+
+func commandToBCS(cmd Command) (map[string]any, error) {
+	// The first invalid argument is reported once the command is encoded.
+	var argErr error
+	encodeArg := func(a Argument) map[string]any {
+		v, err := argumentToBCS(a)
+		if err != nil && argErr == nil {
+			argErr = err
+		}
+		return v
+	}
+	encodeArgs := func(args []Argument) []any {
+		out := make([]any, len(args))
+		for i, a := range args {
+			out[i] = encodeArg(a)
+		}
+		return out
+	}
+	var out map[string]any
+	switch cmd.Kind() {
+	case "MoveCall":
+		tags := make([]any, len(cmd.MoveCall.TypeArguments))
+		for i, t := range cmd.MoveCall.TypeArguments {
+			tags[i] = t
+		}
+		out = map[string]any{
+			"package":       cmd.MoveCall.Package,
+			"module":        cmd.MoveCall.Module,
+			"function":      cmd.MoveCall.Function,
+			"typeArguments": tags,
+			"arguments":     encodeArgs(cmd.MoveCall.Arguments),
+		}
+	case "TransferObjects":
+		out = map[string]any{
+			"objects": encodeArgs(cmd.TransferObjects.Objects),
+			"address": encodeArg(cmd.TransferObjects.Address),
+		}
+	case "SplitCoins":
+		out = map[string]any{
+			"coin":    encodeArg(cmd.SplitCoins.Coin),
+			"amounts": encodeArgs(cmd.SplitCoins.Amounts),
+		}
+	case "MergeCoins":
+		out = map[string]any{
+			"destination": encodeArg(cmd.MergeCoins.Destination),
+			"sources":     encodeArgs(cmd.MergeCoins.Sources),
+		}
+	case "Publish":
+		out = map[string]any{
+			"modules":      modulesToBCS(cmd.Publish.Modules),
+			"dependencies": stringsToBCS(cmd.Publish.Dependencies),
+		}
+	case "Upgrade":
+		out = map[string]any{
+			"modules":      modulesToBCS(cmd.Upgrade.Modules),
+			"dependencies": stringsToBCS(cmd.Upgrade.Dependencies),
+			"package":      cmd.Upgrade.Package,
+			"ticket":       encodeArg(cmd.Upgrade.Ticket),
+		}
+	case "MakeMoveVec":
+		var typ any
+		if t := cmd.MakeMoveVec.Type; t != nil && *t != "" {
+			typ = *t
+		}
+		out = map[string]any{"type": typ, "elements": encodeArgs(cmd.MakeMoveVec.Elements)}
+	case "$Intent":
+		return nil, fmt.Errorf("unresolved %s intent", cmd.Intent.Name)
+	default:
+		return nil, errors.New("empty command")
+	}
+	if argErr != nil {
+		return nil, argErr
+	}
+	return map[string]any{"$kind": cmd.Kind(), cmd.Kind(): out}, nil
+}
+
+func argumentToBCS(arg Argument) (map[string]any, error) {
+	switch arg.Kind {
+	case ArgumentGasCoin:
+		return map[string]any{"$kind": "GasCoin", "GasCoin": true}, nil
+	case ArgumentInput, ArgumentResult:
+		return map[string]any{"$kind": string(arg.Kind), string(arg.Kind): arg.Index}, nil
+	case ArgumentNestedResult:
+		return map[string]any{"$kind": "NestedResult", "NestedResult": []any{arg.Index, arg.ResultIndex}}, nil
+	default:
+		return nil, fmt.Errorf("invalid argument kind: %q", arg.Kind)
+	}
+}
+
+func modulesToBCS(modules [][]byte) []any {
+	out := make([]any, len(modules))
+	for i, m := range modules {
+		out[i] = m
+	}
+	return out
+}
+
+func stringsToBCS(values []string) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+func expirationToBCS(v any) (map[string]any, error) {
+	exp := asMap(v)
+	kind, _ := exp["$kind"].(string)
+	switch kind {
+	case "", "None":
+		return map[string]any{"$kind": "None", "None": true}, nil
+	case "Epoch":
+		epoch, err := toU64String(exp["Epoch"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiration epoch: %w", err)
+		}
+		return map[string]any{"$kind": "Epoch", "Epoch": epoch}, nil
+	case "ValidDuring":
+		vd := asMap(exp["ValidDuring"])
+		out := map[string]any{"chain": vd["chain"]}
+		for _, field := range []string{"minEpoch", "maxEpoch", "minTimestamp", "maxTimestamp"} {
+			if vd[field] == nil {
+				out[field] = nil
+				continue
+			}
+			n, err := toU64String(vd[field])
+			if err != nil {
+				return nil, fmt.Errorf("invalid expiration %s: %w", field, err)
+			}
+			out[field] = n
+		}
+		nonce, err := toU64String(vd["nonce"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiration nonce: %w", err)
+		}
+		n, _ := strconv.ParseUint(nonce, 10, 64)
+		if n > math.MaxUint32 {
+			return nil, fmt.Errorf("invalid expiration nonce: %s", nonce)
+		}
+		out["nonce"] = uint32(n)
+		return map[string]any{"$kind": "ValidDuring", "ValidDuring": out}, nil
+	default:
+		return nil, fmt.Errorf("invalid expiration kind: %q", kind)
+	}
+}
+
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	if m == nil {
+		return map[string]any{}
+	}
+	return m
+}
+
+func toStringSlice(v any) ([]string, error) {
+	switch t := v.(type) {
+	case nil:
+		return []string{}, nil
+	case []string:
+		return t, nil
+	case []any:
+		out := make([]string, len(t))
+		for i, item := range t {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string, got %T", item)
+			}
+			out[i] = s
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("expected string list, got %T", v)
+	}
+}
+
+func toU64String(v any) (string, error) {
+	switch t := v.(type) {
+	case string:
+		if _, err := strconv.ParseUint(t, 10, 64); err != nil {
+			return "", fmt.Errorf("invalid u64 value: %q", t)
+		}
+		return t, nil
+	case json.Number:
+		return toU64String(t.String())
+	case int:
+		if t < 0 {
+			return "", fmt.Errorf("invalid u64 value: %d", t)
+		}
+		return strconv.FormatInt(int64(t), 10), nil
+	case int8:
+		return toU64String(int64(t))
+	case int16:
+		return toU64String(int64(t))
+	case int32:
+		return toU64String(int64(t))
+	case int64:
+		if t < 0 {
+			return "", fmt.Errorf("invalid u64 value: %d", t)
+		}
+		return strconv.FormatInt(t, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(t), 10), nil
+	case uint8:
+		return strconv.FormatUint(uint64(t), 10), nil
+	case uint16:
+		return strconv.FormatUint(uint64(t), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(t), 10), nil
+	case uint64:
+		return strconv.FormatUint(t, 10), nil
+	case float64:
+		if t < 0 || t != math.Trunc(t) || t > math.MaxUint64 {
+			return "", fmt.Errorf("invalid u64 value: %v", t)
+		}
+		return strconv.FormatFloat(t, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("invalid u64 value: %v", v)
+	}
+}
+
+func transactionDataFromBCS(value any) (TransactionData, error) {
+	root, _ := value.(map[string]any)
+	v1, ok := root["V1"].(map[string]any)
+	if !ok {
+		return TransactionData{}, fmt.Errorf("unsupported transaction data version: %v", root["$kind"])
+	}
+	data := TransactionData{Inputs: []CallArg{}, Commands: []Command{}}
+	if err := transactionKindFromBCS(v1["kind"], &data); err != nil {
+		return TransactionData{}, err
+	}
+	data.Sender, _ = v1["sender"].(string)
+	gasData, _ := v1["gasData"].(map[string]any)
+	data.GasData.Owner, _ = gasData["owner"].(string)
+	data.GasData.Price, _ = gasData["price"].(string)
+	data.GasData.Budget, _ = gasData["budget"].(string)
+	payment, _ := gasData["payment"].([]any)
+	data.GasData.Payment = make([]ObjectRef, len(payment))
+	for i, p := range payment {
+		data.GasData.Payment[i] = objectRefFromBCS(p)
+	}
+	data.Expiration = v1["expiration"]
+	return data, nil
+}
+
+func transactionKindFromBCS(value any, data *TransactionData) error {
+	kind, _ := value.(map[string]any)
+	ptb, ok := kind["ProgrammableTransaction"].(map[string]any)
+	if !ok {
+		return fmt.Errorf("unsupported transaction kind: %v", kind["$kind"])
+	}
+	inputs, _ := ptb["inputs"].([]any)
+	for _, input := range inputs {
+		arg, err := callArgFromBCS(input)
+		if err != nil {
+			return err
+		}
+		data.Inputs = append(data.Inputs, arg)
+	}
+	commands, _ := ptb["commands"].([]any)
+	for _, cmd := range commands {
+		c, err := commandFromBCS(cmd)
+		if err != nil {
+			return err
+		}
+		data.Commands = append(data.Commands, c)
+	}
+	return nil
+}
+
+func callArgFromBCS(value any) (CallArg, error) {
+	arg, _ := value.(map[string]any)
+	switch arg["$kind"] {
+	case "Pure":
+		pure, _ := arg["Pure"].(map[string]any)
+		b64, _ := pure["bytes"].(string)
+		bytes, err := base64.StdEncoding.DecodeString(b64)
+		if err != nil {
+			return CallArg{}, fmt.Errorf("invalid pure input: %w", err)
+		}
+		return Inputs.Pure(bytes), nil
+	case "Object":
+		obj, _ := arg["Object"].(map[string]any)
+		switch obj["$kind"] {
+		case "ImmOrOwnedObject":
+			return Inputs.ObjectRef(objectRefFromBCS(obj["ImmOrOwnedObject"])), nil
+		case "Receiving":
+			return Inputs.ReceivingRef(objectRefFromBCS(obj["Receiving"])), nil
+		case "SharedObject":
+			shared, _ := obj["SharedObject"].(map[string]any)
+			objectID, _ := shared["objectId"].(string)
+			version, _ := shared["initialSharedVersion"].(string)
+			mutable, _ := shared["mutable"].(bool)
+			return Inputs.SharedObjectRef(objectID, mutable, version), nil
+		}
+		return CallArg{}, fmt.Errorf("unsupported object input: %v", obj["$kind"])
+	default:
+		return CallArg{}, fmt.Errorf("unsupported input: %v", arg["$kind"])
+	}
+}
+
+func objectRefFromBCS(value any) ObjectRef {
+	ref, _ := value.(map[string]any)
+	objectID, _ := ref["objectId"].(string)
+	version, _ := ref["version"].(string)
+	digest, _ := ref["digest"].(string)
+	return ObjectRef{ObjectID: objectID, Version: version, Digest: digest}
+}
+
+func commandFromBCS(value any) (Command, error) {
+	cmd, _ := value.(map[string]any)
+	kind, _ := cmd["$kind"].(string)
+	payload, _ := cmd[kind].(map[string]any)
+	switch kind {
+	case "MoveCall":
+		pkg, _ := payload["package"].(string)
+		module, _ := payload["module"].(string)
+		function, _ := payload["function"].(string)
+		typeArgs, err := toStringSlice(payload["typeArguments"])
+		if err != nil {
+			return Command{}, err
+		}
+		args, err := argumentsFromBCS(payload["arguments"])
+		if err != nil {
+			return Command{}, err
+		}
+		return Command{MoveCall: &MoveCall{
+			Package:       pkg,
+			Module:        module,
+			Function:      function,
+			TypeArguments: typeArgs,
+			Arguments:     args,
+		}}, nil
+	case "TransferObjects":
+		objects, err := argumentsFromBCS(payload["objects"])
+		if err != nil {
+			return Command{}, err
+		}
+		address, err := argumentFromBCS(payload["address"])
+		if err != nil {
+			return Command{}, err
+		}
+		return TransactionCommands.TransferObjects(objects, address), nil
+	case "SplitCoins":
+		coin, err := argumentFromBCS(payload["coin"])
+		if err != nil {
+			return Command{}, err
+		}
+		amounts, err := argumentsFromBCS(payload["amounts"])
+		if err != nil {
+			return Command{}, err
+		}
+		return TransactionCommands.SplitCoins(coin, amounts), nil
+	case "MergeCoins":
+		destination, err := argumentFromBCS(payload["destination"])
+		if err != nil {
+			return Command{}, err
+		}
+		sources, err := argumentsFromBCS(payload["sources"])
+		if err != nil {
+			return Command{}, err
+		}
+		return TransactionCommands.MergeCoins(destination, sources), nil
+	case "Publish", "Upgrade":
+		rawModules, _ := payload["modules"].([]any)
+		modules := make([][]byte, len(rawModules))
+		for i, m := range rawModules {
+			modules[i], _ = m.([]byte)
+		}
+		deps, err := toStringSlice(payload["dependencies"])
+		if err != nil {
+			return Command{}, err
+		}
+		if kind == "Upgrade" {
+			pkg, _ := payload["package"].(string)
+			ticket, err := argumentFromBCS(payload["ticket"])
+			if err != nil {
+				return Command{}, err
+			}
+			return Command{Upgrade: &Upgrade{
+				Modules:      modules,
+				Dependencies: deps,
+				Package:      pkg,
+				Ticket:       ticket,
+			}}, nil
+		}
+		return TransactionCommands.Publish(modules, deps), nil
+	case "MakeMoveVec":
+		var typ *string
+		if s, ok := payload["type"].(string); ok {
+			typ = &s
+		}
+		elements, err := argumentsFromBCS(payload["elements"])
+		if err != nil {
+			return Command{}, err
+		}
+		return TransactionCommands.MakeMoveVec(typ, elements), nil
+	default:
+		return Command{}, fmt.Errorf("unsupported command: %q", kind)
+	}
+}
+
+func argumentsFromBCS(value any) ([]Argument, error) {
+	items, _ := value.([]any)
+	out := make([]Argument, len(items))
+	for i, item := range items {
+		arg, err := argumentFromBCS(item)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = arg
+	}
+	return out, nil
+}
+
+func argumentFromBCS(value any) (Argument, error) {
+	arg, _ := value.(map[string]any)
+	switch arg["$kind"] {
+	case "GasCoin":
+		return GasCoinArgument(), nil
+	case "Input":
+		n, _ := arg["Input"].(uint16)
+		return InputArgument(n), nil
+	case "Result":
+		n, _ := arg["Result"].(uint16)
+		return ResultArgument(n), nil
+	case "NestedResult":
+		pair, _ := arg["NestedResult"].([]any)
+		if len(pair) != 2 {
+			return Argument{}, fmt.Errorf("invalid nested result argument: %v", arg["NestedResult"])
+		}
+		a, _ := pair[0].(uint16)
+		b, _ := pair[1].(uint16)
+		return NestedResultArgument(a, b), nil
+	default:
+		return Argument{}, fmt.Errorf("unsupported argument: %v", arg["$kind"])
+	}
+}
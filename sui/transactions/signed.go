@@ -0,0 +1,136 @@
+package transactions
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/sui-sdks/go-sdks/bcs"
+	"github.com/sui-sdks/go-sdks/sui/cryptography"
+)
+
+const transactionDigestPrefix = "TransactionData::"
+
+// TransactionDigestFromBytes returns the base58 digest of BCS TransactionData
+// bytes: Blake2b-256 over the "TransactionData::" prefixed bytes.
+func TransactionDigestFromBytes(bytes []byte) string {
+	digest := cryptography.Blake2b256([]byte(transactionDigestPrefix), bytes)
+	return bcs.ToBase58(digest[:])
+}
+
+// GetDigest builds the full transaction data and returns the digest the
+// network will assign to it. Each call resolves the transaction again, so
+// when the client's gas price or coins change in between, Sign may sign other
+// bytes; call both on the result of Prepare to keep them the same.
+func (t *Transaction) GetDigest(options ...BuildTransactionOptions) (string, error) {
+	var opts BuildTransactionOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	opts.OnlyTransactionKind = false
+	bytes, err := t.Build(opts)
+	if err != nil {
+		return "", err
+	}
+	return TransactionDigestFromBytes(bytes), nil
+}
+
+// Sign builds the transaction and signs it, returning the signed envelope.
+func (t *Transaction) Sign(signer interface {
+	SignTransaction([]byte) (cryptography.SignatureWithBytes, error)
+}, options ...BuildTransactionOptions) (*SignedTransaction, error) {
+	bytes, err := t.Build(options...)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer.SignTransaction(bytes)
+	if err != nil {
+		return nil, err
+	}
+	return NewSignedTransaction(bytes, sig.Signature), nil
+}
+
+// SignedTransaction bundles BCS TransactionData bytes with the serialized
+// signatures authorizing it.
+type SignedTransaction struct {
+	Bytes      []byte
+	Signatures []string
+}
+
+func NewSignedTransaction(bytes []byte, signatures ...string) *SignedTransaction {
+	return &SignedTransaction{Bytes: append([]byte(nil), bytes...), Signatures: append([]string(nil), signatures...)}
+}
+
+func (s *SignedTransaction) AddSignature(signature string) {
+	s.Signatures = append(s.Signatures, signature)
+}
+
+func (s *SignedTransaction) Digest() string { return TransactionDigestFromBytes(s.Bytes) }
+
+func (s *SignedTransaction) BytesBase64() string { return base64.StdEncoding.EncodeToString(s.Bytes) }
+
+// Transaction restores the unsigned transaction from the signed bytes.
+func (s *SignedTransaction) Transaction() (*Transaction, error) { return TransactionFromBytes(s.Bytes) }
+
+// ToBCS serializes the envelope as SenderSignedData.
+func (s *SignedTransaction) ToBCS() ([]byte, error) {
+	data, err := BCS.TransactionData.Parse(s.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction data: %w", err)
+	}
+	signatures := make([]any, len(s.Signatures))
+	for i, sig := range s.Signatures {
+		raw, err := base64.StdEncoding.DecodeString(sig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature %d: %w", i, err)
+		}
+		signatures[i] = raw
+	}
+	serialized, err := BCS.SenderSignedData.Serialize([]any{map[string]any{
+		"intentMessage": map[string]any{
+			"intent": map[string]any{
+				"scope":   map[string]any{"TransactionData": true},
+				"version": map[string]any{"V0": true},
+				"appId":   map[string]any{"Sui": true},
+			},
+			"value": data,
+		},
+		"txSignatures": signatures,
+	}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return serialized.ToBytes(), nil
+}
+
+// SignedTransactionFromBCS parses SenderSignedData bytes.
+func SignedTransactionFromBCS(bytes []byte) (*SignedTransaction, error) {
+	parsed, err := BCS.SenderSignedData.Parse(bytes)
+	if err != nil {
+		return nil, err
+	}
+	txs, _ := parsed.([]any)
+	if len(txs) != 1 {
+		return nil, fmt.Errorf("expected exactly one sender signed transaction, got %d", len(txs))
+	}
+	signed := asMap(txs[0])
+	message := asMap(signed["intentMessage"])
+	intent := asMap(message["intent"])
+	if asMap(intent["scope"])["$kind"] != "TransactionData" {
+		return nil, errors.New("sender signed data does not carry a TransactionData intent")
+	}
+	data, err := BCS.TransactionData.Serialize(message["value"], nil)
+	if err != nil {
+		return nil, err
+	}
+	out := &SignedTransaction{Bytes: data.ToBytes()}
+	sigs, _ := signed["txSignatures"].([]any)
+	for _, sig := range sigs {
+		raw, ok := sig.([]byte)
+		if !ok {
+			return nil, errors.New("invalid transaction signature")
+		}
+		out.Signatures = append(out.Signatures, base64.StdEncoding.EncodeToString(raw))
+	}
+	return out, nil
+}
@@ -0,0 +1,134 @@
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sui-sdks/go-sdks/sui/cryptography"
+)
+
+// Digest computed independently as base58(blake2b-256("TransactionData::" || bytes)).
+const goldenSplitTransferDigest = "BsFYwcMZp6M8ovt6YcK4jp8CaQYfgz7hnmWvsBKdZ2B9"
+
+type staticSigner struct{ signature string }
+
+func (s staticSigner) SignTransaction(b []byte) (cryptography.SignatureWithBytes, error) {
+	return cryptography.SignatureWithBytes{Bytes: base64.StdEncoding.EncodeToString(b), Signature: s.signature}, nil
+}
+
+func TestTransactionDigest(t *testing.T) {
+	raw, _ := hex.DecodeString(goldenSplitTransferTx)
+	if got := TransactionDigestFromBytes(raw); got != goldenSplitTransferDigest {
+		t.Fatalf("digest mismatch: got %s want %s", got, goldenSplitTransferDigest)
+	}
+	tx, err := TransactionFromBytes(raw)
+	if err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	digest, err := tx.GetDigest()
+	if err != nil {
+		t.Fatalf("get digest failed: %v", err)
+	}
+	if digest != goldenSplitTransferDigest {
+		t.Fatalf("GetDigest mismatch: got %s want %s", digest, goldenSplitTransferDigest)
+	}
+}
+
+// driftingCore raises the reference gas price on every call, as a network
+// does across epochs.
+type driftingCore struct {
+	mockCore
+	price atomic.Int64
+}
+
+func (c *driftingCore) Call(ctx context.Context, method string, params []any, out any) error {
+	if p, ok := out.(*string); ok && method == "suix_getReferenceGasPrice" {
+		*p = strconv.FormatInt(1000+c.price.Add(1), 10)
+		return nil
+	}
+	return c.mockCore.Call(ctx, method, params, out)
+}
+
+func TestPreparedDigestMatchesSignature(t *testing.T) {
+	tx := NewTransaction()
+	tx.SetSender("0x1")
+	tx.SplitCoins(tx.Gas(), []Argument{tx.Pure().U64(1)})
+	opts := BuildTransactionOptions{Client: &driftingCore{}}
+	signer := staticSigner{signature: "sig"}
+
+	digest, _ := tx.GetDigest(opts)
+	signed, err := tx.Sign(signer, opts)
+	if err != nil {
+		t.Fatalf("sign failed: %v", err)
+	}
+	if digest == signed.Digest() {
+		t.Fatal("expected the drifting gas price to change the unprepared transaction")
+	}
+
+	prepared, err := tx.Prepare(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("prepare failed: %v", err)
+	}
+	digest, err = prepared.GetDigest(opts)
+	if err != nil {
+		t.Fatalf("get digest failed: %v", err)
+	}
+	signed, err = prepared.Sign(signer, opts)
+	if err != nil {
+		t.Fatalf("sign failed: %v", err)
+	}
+	if digest != signed.Digest() {
+		t.Fatalf("digest %s does not match the signed transaction %s", digest, signed.Digest())
+	}
+	if tx.GetData().GasData.Price != "" {
+		t.Fatal("prepare must not modify the transaction")
+	}
+}
+
+func TestSignedTransactionBCS(t *testing.T) {
+	raw, _ := hex.DecodeString(goldenSplitTransferTx)
+	tx, err := TransactionFromBytes(raw)
+	if err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	sigBytes := append(append([]byte{0}, make([]byte, 64)...), bytes.Repeat([]byte{7}, 32)...)
+	for i := 0; i < 64; i++ {
+		sigBytes[1+i] = byte(i)
+	}
+	sig := base64.StdEncoding.EncodeToString(sigBytes)
+
+	signed, err := tx.Sign(staticSigner{signature: sig})
+	if err != nil {
+		t.Fatalf("sign failed: %v", err)
+	}
+	if signed.Digest() != goldenSplitTransferDigest || signed.BytesBase64() != base64.StdEncoding.EncodeToString(raw) {
+		t.Fatalf("unexpected signed transaction: %+v", signed)
+	}
+
+	encoded, err := signed.ToBCS()
+	if err != nil {
+		t.Fatalf("to bcs failed: %v", err)
+	}
+	// vector len 1, intent (0,0,0), transaction data, vector<vector<u8>> with one 97-byte signature.
+	want := "01" + "000000" + goldenSplitTransferTx + "01" + "61" + hex.EncodeToString(sigBytes)
+	if got := hex.EncodeToString(encoded); got != want {
+		t.Fatalf("unexpected sender signed data:\n got %s\nwant %s", got, want)
+	}
+
+	decoded, err := SignedTransactionFromBCS(encoded)
+	if err != nil {
+		t.Fatalf("from bcs failed: %v", err)
+	}
+	if !bytes.Equal(decoded.Bytes, raw) || len(decoded.Signatures) != 1 || decoded.Signatures[0] != sig {
+		t.Fatalf("round trip mismatch: %+v", decoded)
+	}
+
+	if _, err := SignedTransactionFromBCS([]byte{0}); err == nil {
+		t.Fatalf("expected error for empty sender signed data")
+	}
+}
@@ -0,0 +1,171 @@
+package transactions
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/sui-sdks/go-sdks/sui/cryptography"
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+// Sponsored transactions are built in three steps:
+//
+//  1. The sender builds the transaction kind with BuildKind and hands the
+//     bytes to the sponsor.
+//  2. The sponsor calls SponsorTransaction, which attaches the sponsor's gas
+//     and returns a SignedTransaction carrying the sponsor signature.
+//  3. The sender checks the result with MatchesKind, adds its own signature
+//     with Sign and executes the combined envelope.
+
+// BuildKind builds only the TransactionKind bytes, leaving sender and gas
+// data to be filled in by a sponsor.
+func (t *Transaction) BuildKind(options ...BuildTransactionOptions) ([]byte, error) {
+	var opts BuildTransactionOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	opts.OnlyTransactionKind = true
+	return t.Build(opts)
+}
+
+// TransactionFromKind restores a transaction from BCS TransactionKind bytes.
+func TransactionFromKind(kind []byte) (*Transaction, error) {
+	parsed, err := BCS.TransactionKind.Parse(kind)
+	if err != nil {
+		return nil, err
+	}
+	tx := NewTransaction()
+	if err := transactionKindFromBCS(parsed, &tx.data); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+type SponsorTransactionOptions struct {
+	// Client resolves the gas price, budget and payment that are not set.
+	Client     CoreClient
+	GasPrice   any
+	GasBudget  any
+	GasPayment []ObjectRef
+	// AllowGasCoinUsage lets the transaction kind use the sponsor's gas coin
+	// as an argument. It is rejected by default, since a sender could
+	// otherwise spend the sponsor's SUI.
+	AllowGasCoinUsage bool
+}
+
+// SponsorTransaction attaches the sponsor's gas to the sender's transaction
+// kind and signs the resulting transaction data as gas owner.
+func SponsorTransaction(kind []byte, sender string, sponsor interface {
+	ToSuiAddress() string
+	SignTransaction([]byte) (cryptography.SignatureWithBytes, error)
+}, opts SponsorTransactionOptions) (*SignedTransaction, error) {
+	tx, err := sponsoredTransaction(kind, sender, sponsor.ToSuiAddress(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return tx.Sign(sponsor, BuildTransactionOptions{Client: opts.Client})
+}
+
+func sponsoredTransaction(kind []byte, sender, sponsor string, opts SponsorTransactionOptions) (*Transaction, error) {
+	if sender == "" {
+		return nil, errors.New("missing transaction sender")
+	}
+	tx, err := TransactionFromKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.AllowGasCoinUsage {
+		for i, cmd := range tx.data.Commands {
+			for _, arg := range cmd.Arguments() {
+				if arg.Kind == ArgumentGasCoin {
+					return nil, fmt.Errorf("command %d uses the gas coin of a sponsored transaction", i)
+				}
+			}
+		}
+	}
+	tx.SetSender(sender)
+	tx.SetGasOwner(sponsor)
+	if opts.GasPrice != nil {
+		tx.SetGasPrice(opts.GasPrice)
+	}
+	if opts.GasBudget != nil {
+		tx.SetGasBudget(opts.GasBudget)
+	}
+	if opts.GasPayment != nil {
+		tx.SetGasPayment(opts.GasPayment)
+	}
+	return tx, nil
+}
+
+// Signers returns the addresses that must sign the transaction: the sender,
+// followed by the gas owner when it differs.
+func (s *SignedTransaction) Signers() ([]string, error) {
+	tx, err := s.Transaction()
+	if err != nil {
+		return nil, err
+	}
+	sender := utils.NormalizeSuiAddress(tx.data.Sender)
+	owner := utils.NormalizeSuiAddress(tx.data.GasData.Owner)
+	if owner == sender {
+		return []string{sender}, nil
+	}
+	return []string{sender, owner}, nil
+}
+
+// Sign adds the signer's signature to the envelope.
+func (s *SignedTransaction) Sign(signer interface {
+	SignTransaction([]byte) (cryptography.SignatureWithBytes, error)
+}) error {
+	sig, err := signer.SignTransaction(s.Bytes)
+	if err != nil {
+		return err
+	}
+	s.AddSignature(sig.Signature)
+	return nil
+}
+
+// MatchesKind reports whether the signed transaction carries exactly the
+// given transaction kind, so a sender can check what a sponsor returned
+// before signing it.
+func (s *SignedTransaction) MatchesKind(kind []byte) (bool, error) {
+	tx, err := s.Transaction()
+	if err != nil {
+		return false, err
+	}
+	signedKind, err := tx.BuildKind()
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(signedKind, kind), nil
+}
+
+// executorSignatures returns the signatures an executor submits for a signed
+// transaction, adding the executor's own signature when it is a required
+// signer that has not signed yet.
+func executorSignatures(signed *SignedTransaction, signer interface {
+	ToSuiAddress() string
+	SignTransaction([]byte) (cryptography.SignatureWithBytes, error)
+}) ([]string, error) {
+	signers, err := signed.Signers()
+	if err != nil {
+		return nil, err
+	}
+	sigs := append([]string{}, signed.Signatures...)
+	if len(sigs) >= len(signers) {
+		return sigs, nil
+	}
+	address := utils.NormalizeSuiAddress(signer.ToSuiAddress())
+	required := false
+	for _, s := range signers {
+		required = required || s == address
+	}
+	if !required {
+		return nil, fmt.Errorf("signer %s is not a required signer of the transaction", address)
+	}
+	sig, err := signer.SignTransaction(signed.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return append(sigs, sig.Signature), nil
+}
@@ -0,0 +1,176 @@
+package transactions
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	edkp "github.com/sui-sdks/go-sdks/sui/keypairs/ed25519"
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+// executeRecorder records the signatures submitted to sui_executeTransactionBlock.
+type executeRecorder struct {
+	mockCore
+	signatures [][]string
+}
+
+func (r *executeRecorder) Call(ctx context.Context, method string, params []any, out any) error {
+	if method == "sui_executeTransactionBlock" {
+		r.signatures = append(r.signatures, params[1].([]string))
+	}
+	return r.mockCore.Call(ctx, method, params, out)
+}
+
+func TestSponsoredTransactionWorkflow(t *testing.T) {
+	sender, _ := edkp.Generate()
+	sponsor, _ := edkp.Generate()
+	client := &executeRecorder{}
+
+	tx := NewTransaction()
+	tx.TransferObjects([]Argument{tx.Object(Inputs.ObjectRef(ObjectRef{ObjectID: "0xa", Version: "1", Digest: testDigest}))}, recipient(t, tx))
+	kind, err := tx.BuildKind()
+	if err != nil {
+		t.Fatalf("build kind failed: %v", err)
+	}
+
+	sponsorExec := NewSerialTransactionExecutor(SerialTransactionExecutorOptions{Client: client, Signer: sponsor})
+	signed, err := sponsorExec.SponsorTransaction(kind, sender.ToSuiAddress())
+	if err != nil {
+		t.Fatalf("sponsor failed: %v", err)
+	}
+	data, _ := signed.Transaction()
+	if data.GetData().GasData.Owner != utils.NormalizeSuiAddress(sponsor.ToSuiAddress()) || len(data.GetData().GasData.Payment) != 1 {
+		t.Fatalf("sponsor gas not attached: %+v", data.GetData().GasData)
+	}
+	if ok, err := signed.MatchesKind(kind); err != nil || !ok {
+		t.Fatalf("sponsored transaction must carry the sender's kind: %v", err)
+	}
+	signers, _ := signed.Signers()
+	if len(signers) != 2 || len(signed.Signatures) != 1 {
+		t.Fatalf("expected two signers and the sponsor signature, got %v / %d", signers, len(signed.Signatures))
+	}
+
+	senderExec := NewSerialTransactionExecutor(SerialTransactionExecutorOptions{Client: client, Signer: sender})
+	if _, err := senderExec.ExecuteTransaction(signed, nil, nil); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	if len(client.signatures) != 1 || len(client.signatures[0]) != 2 {
+		t.Fatalf("expected sender and sponsor signatures, got %v", client.signatures)
+	}
+
+	// A fully signed envelope is executed as is.
+	if err := signed.Sign(sender); err != nil {
+		t.Fatalf("sender sign failed: %v", err)
+	}
+	if _, err := NewCachingTransactionExecutor(client, nil).ExecuteSignedTransaction(signed, nil); err != nil {
+		t.Fatalf("execute signed failed: %v", err)
+	}
+	parallel := NewParallelTransactionExecutor(ParallelTransactionExecutorOptions{Client: client, Signer: sender})
+	if _, err := parallel.ExecuteSignedTransaction(signed, nil); err != nil {
+		t.Fatalf("parallel execute signed failed: %v", err)
+	}
+	if n := len(client.signatures); n != 3 || len(client.signatures[1]) != 2 || len(client.signatures[2]) != 2 {
+		t.Fatalf("unexpected submitted signatures: %v", client.signatures)
+	}
+
+	// The mock's only coin is still leased to the first sponsorship, which
+	// was executed elsewhere, so sponsor again from a new executor.
+	stranger, _ := edkp.Generate()
+	sponsorExec = NewSerialTransactionExecutor(SerialTransactionExecutorOptions{Client: client, Signer: sponsor})
+	partial, _ := sponsorExec.SponsorTransaction(kind, sender.ToSuiAddress())
+	strangerExec := NewSerialTransactionExecutor(SerialTransactionExecutorOptions{Client: client, Signer: stranger})
+	if _, err := strangerExec.ExecuteTransaction(partial, nil, nil); err == nil || !strings.Contains(err.Error(), "not a required signer") {
+		t.Fatalf("expected required signer error, got %v", err)
+	}
+}
+
+func TestSponsorTransactionRejectsGasCoinUsage(t *testing.T) {
+	sponsor, _ := edkp.Generate()
+	tx := NewTransaction()
+	tx.SplitCoins(tx.Gas(), []Argument{tx.Pure().U64(1)})
+	kind, err := tx.BuildKind()
+	if err != nil {
+		t.Fatalf("build kind failed: %v", err)
+	}
+	opts := SponsorTransactionOptions{Client: mockCore{}}
+	if _, err := SponsorTransaction(kind, "0x1", sponsor, opts); err == nil || !strings.Contains(err.Error(), "gas coin") {
+		t.Fatalf("expected gas coin error, got %v", err)
+	}
+	opts.AllowGasCoinUsage = true
+	if _, err := SponsorTransaction(kind, "0x1", sponsor, opts); err != nil {
+		t.Fatalf("sponsor with gas coin usage allowed failed: %v", err)
+	}
+}
+
+func TestSponsorTransactionLeasesGasCoins(t *testing.T) {
+	sender, _ := edkp.Generate()
+	sponsor, _ := edkp.Generate()
+	client := &coinsCore{coins: mockCoins(3, "1000000000")}
+	exec := NewSerialTransactionExecutor(SerialTransactionExecutorOptions{Client: client, Signer: sponsor})
+	tx := NewTransaction()
+	tx.MoveCall("0x2::example::run", nil, nil)
+	kind, err := tx.BuildKind()
+	if err != nil {
+		t.Fatalf("build kind failed: %v", err)
+	}
+	payment := func(signed *SignedTransaction) string {
+		t.Helper()
+		data, err := signed.Transaction()
+		if err != nil || len(data.GetData().GasData.Payment) != 1 {
+			t.Fatalf("expected one gas coin, got %v", err)
+		}
+		return data.GetData().GasData.Payment[0].ObjectID
+	}
+
+	first, err := exec.SponsorTransaction(kind, sender.ToSuiAddress())
+	if err != nil {
+		t.Fatalf("first sponsor failed: %v", err)
+	}
+	second, err := exec.SponsorTransaction(kind, sender.ToSuiAddress())
+	if err != nil {
+		t.Fatalf("second sponsor failed: %v", err)
+	}
+	if payment(first) == payment(second) {
+		t.Fatalf("back-to-back sponsorships share gas coin %s", payment(first))
+	}
+
+	// The sponsor's own transactions skip the leased coins too.
+	own := NewTransaction()
+	own.MoveCall("0x2::example::run", nil, nil)
+	if _, err := exec.ExecuteTransaction(own, nil, nil); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	gas := client.executed[0].GasData.Payment
+	if len(gas) != 1 || gas[0].ObjectID == payment(first) || gas[0].ObjectID == payment(second) {
+		t.Fatalf("own transaction paid with a leased coin: %+v", gas)
+	}
+	if _, err := exec.SponsorTransaction(kind, sender.ToSuiAddress()); err == nil || !strings.Contains(err.Error(), "no valid gas coins") {
+		t.Fatalf("expected every coin to be in use, got %v", err)
+	}
+
+	// Executing a sponsored transaction ends its lease.
+	if err := first.Sign(sender); err != nil {
+		t.Fatalf("sender sign failed: %v", err)
+	}
+	if _, err := exec.ExecuteTransaction(first, nil, nil); err != nil {
+		t.Fatalf("execute sponsored failed: %v", err)
+	}
+	third, err := exec.SponsorTransaction(kind, sender.ToSuiAddress())
+	if err != nil {
+		t.Fatalf("sponsor after execution failed: %v", err)
+	}
+	if payment(third) != payment(first) {
+		t.Fatalf("expected the executed transaction's coin %s to be reused, got %s", payment(first), payment(third))
+	}
+
+	// So does the end of the lease.
+	short := NewSerialTransactionExecutor(SerialTransactionExecutorOptions{Client: client, Signer: sponsor, SponsorLease: time.Nanosecond})
+	a, _ := short.SponsorTransaction(kind, sender.ToSuiAddress())
+	time.Sleep(time.Millisecond)
+	b, err := short.SponsorTransaction(kind, sender.ToSuiAddress())
+	if err != nil || payment(a) != payment(b) {
+		t.Fatalf("expected an expired lease to free the coin: %v", err)
+	}
+}
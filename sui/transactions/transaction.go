@@ -1,30 +1,34 @@
 package transactions
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/sui-sdks/go-sdks/sui/utils"
 )
 
 type GasData struct {
-	Owner   string
-	Price   string
-	Budget  string
-	Payment []ObjectRef
+	Owner   string      `json:"owner"`
+	Price   string      `json:"price"`
+	Budget  string      `json:"budget"`
+	Payment []ObjectRef `json:"payment"`
 }
 
 type TransactionData struct {
-	Sender     string
-	Expiration any
-	GasData    GasData
-	Inputs     []CallArg
-	Commands   []Command
+	Sender     string    `json:"sender"`
+	Expiration any       `json:"expiration"`
+	GasData    GasData   `json:"gasData"`
+	Inputs     []CallArg `json:"inputs"`
+	Commands   []Command `json:"commands"`
 }
 
 type Transaction struct {
-	data TransactionData
+	data            TransactionData
+	buildPlugins    []TransactionPlugin
+	intentResolvers map[string]TransactionPlugin
 }
 
 func NewTransaction() *Transaction {
@@ -43,8 +47,23 @@ func TransactionFrom(serialized string) (*Transaction, error) {
 	if err != nil {
 		return nil, err
 	}
-	var data TransactionData
-	if err := json.Unmarshal(b, &data); err != nil {
+	if len(b) > 0 && b[0] == '{' {
+		var data TransactionData
+		if err := json.Unmarshal(b, &data); err != nil {
+			return nil, err
+		}
+		return &Transaction{data: data}, nil
+	}
+	return TransactionFromBytes(b)
+}
+
+func TransactionFromBytes(bytes []byte) (*Transaction, error) {
+	parsed, err := BCS.TransactionData.Parse(bytes)
+	if err != nil {
+		return nil, err
+	}
+	data, err := transactionDataFromBCS(parsed)
+	if err != nil {
 		return nil, err
 	}
 	return &Transaction{data: data}, nil
@@ -68,23 +87,25 @@ func (t *Transaction) SetGasBudgetIfNotSet(budget any) {
 func (t *Transaction) SetGasOwner(owner string) { t.data.GasData.Owner = utils.NormalizeSuiAddress(owner) }
 func (t *Transaction) SetGasPayment(payments []ObjectRef) { t.data.GasData.Payment = payments }
 
-func (t *Transaction) Gas() Argument { return Argument{"$kind": "GasCoin", "GasCoin": true} }
+func (t *Transaction) Gas() Argument { return GasCoinArgument() }
 
 func (t *Transaction) AddInput(arg CallArg) Argument {
 	t.data.Inputs = append(t.data.Inputs, arg)
-	return Argument{"$kind": "Input", "Input": len(t.data.Inputs) - 1}
+	return InputArgument(uint16(len(t.data.Inputs) - 1))
 }
 
 func (t *Transaction) Object(value any) Argument {
 	switch v := value.(type) {
 	case string:
-		return t.AddInput(CallArg{"$kind": "UnresolvedObject", "UnresolvedObject": map[string]any{"objectId": utils.NormalizeSuiObjectID(v)}})
+		return t.AddInput(CallArg{UnresolvedObject: &UnresolvedObject{ObjectID: utils.NormalizeSuiObjectID(v)}})
+	case ObjectRef:
+		return t.AddInput(Inputs.ObjectRef(v))
 	case CallArg:
 		return t.AddInput(v)
 	case Argument:
 		return v
 	default:
-		return t.AddInput(CallArg{"$kind": "UnresolvedObject", "UnresolvedObject": map[string]any{"value": v}})
+		return t.AddInput(CallArg{UnresolvedObject: &UnresolvedObject{ObjectID: utils.NormalizeSuiObjectID(toString(v))}})
 	}
 }
 
@@ -94,7 +115,7 @@ func (t *Transaction) PureBytes(value []byte) Argument {
 
 func (t *Transaction) AddCommand(cmd Command) Argument {
 	t.data.Commands = append(t.data.Commands, cmd)
-	return Argument{"$kind": "Result", "Result": len(t.data.Commands) - 1}
+	return ResultArgument(uint16(len(t.data.Commands) - 1))
 }
 
 func (t *Transaction) MoveCall(target string, args []Argument, typeArgs []string) Argument {
@@ -117,20 +138,127 @@ func (t *Transaction) Publish(modules [][]byte, dependencies []string) Argument
 	return t.AddCommand(TransactionCommands.Publish(modules, dependencies))
 }
 
-func (t *Transaction) Build() ([]byte, error) {
-	return json.Marshal(t.data)
+// Build resolves a snapshot of the transaction and returns its BCS bytes. The
+// transaction itself is left unchanged, so it can be built again, for
+// example on retry, or from several goroutines.
+func (t *Transaction) Build(options ...BuildTransactionOptions) ([]byte, error) {
+	var opts BuildTransactionOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	return t.build(opts, nil)
+}
+
+// BuildContext is Build with a context that bounds every client call made
+// while resolving the transaction.
+func (t *Transaction) BuildContext(ctx context.Context, options ...BuildTransactionOptions) ([]byte, error) {
+	var opts BuildTransactionOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	opts.ctx = ctx
+	return t.build(opts, nil)
+}
+
+// Prepare resolves a copy of the transaction for full transaction data and
+// returns it. The copy's gas and inputs are final, so digesting, building and
+// signing it give the same bytes without asking a client again. Its own
+// build plugins have already run and are not kept.
+func (t *Transaction) Prepare(ctx context.Context, opts BuildTransactionOptions) (*Transaction, error) {
+	opts.ctx = ctx
+	opts.OnlyTransactionKind = false
+	snapshot, opts, err := t.resolve(opts, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := snapshot.serialize(opts); err != nil {
+		return nil, err
+	}
+	snapshot.buildPlugins = nil
+	return snapshot, nil
+}
+
+// build resolves and serializes a snapshot of the transaction, so t itself is
+// never modified and may be built from several goroutines at once.
+func (t *Transaction) build(opts BuildTransactionOptions, extra []TransactionPlugin) ([]byte, error) {
+	snapshot, opts, err := t.resolve(opts, extra)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.serialize(opts)
+}
+
+// resolve runs the build plugins on a snapshot of the transaction.
+func (t *Transaction) resolve(opts BuildTransactionOptions, extra []TransactionPlugin) (*Transaction, BuildTransactionOptions, error) {
+	snapshot := t.Clone()
+	plugins, opts := snapshot.buildPipeline(opts, extra)
+	if err := runTransactionPlugins(&snapshot.data, opts, plugins); err != nil {
+		return nil, opts, err
+	}
+	if err := validateResolvedInputs(&snapshot.data); err != nil {
+		return nil, opts, err
+	}
+	return snapshot, opts, nil
+}
+
+// serialize checks a resolved transaction against the protocol limits and
+// returns its BCS bytes.
+func (t *Transaction) serialize(opts BuildTransactionOptions) ([]byte, error) {
+	limits, err := protocolLimits(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateLimits(&t.data, limits, opts.OnlyTransactionKind); err != nil {
+		return nil, err
+	}
+	if opts.OnlyTransactionKind {
+		value, err := transactionKindToBCS(&t.data)
+		if err != nil {
+			return nil, err
+		}
+		serialized, err := BCS.TransactionKind.Serialize(value, nil)
+		if err != nil {
+			return nil, err
+		}
+		return serialized.ToBytes(), nil
+	}
+	if t.data.Sender == "" {
+		return nil, errors.New("missing transaction sender")
+	}
+	if t.data.GasData.Budget == "" {
+		return nil, errors.New("missing gas budget")
+	}
+	if t.data.GasData.Price == "" {
+		return nil, errors.New("missing gas price")
+	}
+	value, err := transactionDataToBCS(&t.data)
+	if err != nil {
+		return nil, err
+	}
+	serialized, err := BCS.TransactionData.Serialize(value, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTransactionSize(serialized.ToBytes(), limits); err != nil {
+		return nil, err
+	}
+	return serialized.ToBytes(), nil
 }
 
-func (t *Transaction) BuildBase64() (string, error) {
-	b, err := t.Build()
+func (t *Transaction) BuildBase64(options ...BuildTransactionOptions) (string, error) {
+	b, err := t.Build(options...)
 	if err != nil {
 		return "", err
 	}
 	return base64.StdEncoding.EncodeToString(b), nil
 }
 
+// Serialize encodes the transaction using the TS SDK's v2 transaction JSON schema.
 func (t *Transaction) Serialize() (string, error) {
-	b, err := json.Marshal(t.data)
+	b, err := json.Marshal(struct {
+		Version int `json:"version"`
+		TransactionData
+	}{Version: 2, TransactionData: t.data})
 	if err != nil {
 		return "", err
 	}
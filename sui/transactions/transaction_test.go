@@ -1,6 +1,9 @@
 package transactions
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestTransactionBuildSerialize(t *testing.T) {
 	tx := NewTransaction()
@@ -32,3 +35,46 @@ func TestTransactionBuildSerialize(t *testing.T) {
 		t.Fatalf("missing sender")
 	}
 }
+
+func TestTransactionSerializeV2JSON(t *testing.T) {
+	tx := NewTransaction()
+	tx.SetSender("0x1")
+	tx.SetGasBudget(1000)
+	tx.SetGasPrice(1)
+	coin := tx.SplitCoins(tx.Gas(), []Argument{tx.PureBytes([]byte{10})})
+	tx.TransferObjects([]Argument{coin.Nested(0)}, tx.Object("0x2"))
+
+	serialized, err := tx.Serialize()
+	if err != nil {
+		t.Fatalf("serialize failed: %v", err)
+	}
+	for _, want := range []string{
+		`"version":2`,
+		`{"$kind":"GasCoin","GasCoin":true}`,
+		`{"$kind":"NestedResult","NestedResult":[0,0]}`,
+		`{"$kind":"Pure","Pure":{"bytes":"Cg=="}}`,
+		`{"$kind":"UnresolvedObject","UnresolvedObject":{"objectId":"0x0000000000000000000000000000000000000000000000000000000000000002"}}`,
+	} {
+		if !strings.Contains(serialized, want) {
+			t.Fatalf("serialized transaction missing %s:\n%s", want, serialized)
+		}
+	}
+
+	restored, err := TransactionFrom(serialized)
+	if err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	cmds := restored.GetData().Commands
+	if len(cmds) != 2 || cmds[0].SplitCoins == nil || cmds[1].TransferObjects == nil {
+		t.Fatalf("unexpected restored commands: %+v", cmds)
+	}
+	if got := cmds[1].TransferObjects.Address; got != InputArgument(1) {
+		t.Fatalf("unexpected transfer address argument: %+v", got)
+	}
+	if got := cmds[1].TransferObjects.Objects[0]; got != NestedResultArgument(0, 0) {
+		t.Fatalf("unexpected transfer object argument: %+v", got)
+	}
+	if restored.GetData().Inputs[1].UnresolvedObject == nil {
+		t.Fatalf("expected unresolved object input, got %s", restored.GetData().Inputs[1].Kind())
+	}
+}
@@ -0,0 +1,111 @@
+package transactions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sui-sdks/go-sdks/bcs"
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+var primitiveTypeTags = map[string]bool{
+	"bool":    true,
+	"u8":      true,
+	"u16":     true,
+	"u32":     true,
+	"u64":     true,
+	"u128":    true,
+	"u256":    true,
+	"address": true,
+	"signer":  true,
+}
+
+func parseTypeTag(str string) (map[string]any, error) {
+	str = strings.TrimSpace(str)
+	if primitiveTypeTags[str] {
+		return map[string]any{"$kind": str, str: true}, nil
+	}
+	if strings.HasPrefix(str, "vector<") && strings.HasSuffix(str, ">") {
+		inner, err := parseTypeTag(str[len("vector<") : len(str)-1])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"$kind": "vector", "vector": inner}, nil
+	}
+	structTag, err := parseStructTag(str)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"$kind": "struct", "struct": structTag}, nil
+}
+
+func parseStructTag(str string) (map[string]any, error) {
+	base := str
+	typeParams := []any{}
+	if i := strings.Index(str, "<"); i >= 0 {
+		if !strings.HasSuffix(str, ">") {
+			return nil, fmt.Errorf("invalid type tag: %s", str)
+		}
+		base = str[:i]
+		for _, param := range bcs.SplitGenericParameters(str[i+1:len(str)-1], [2]rune{'<', '>'}) {
+			tag, err := parseTypeTag(param)
+			if err != nil {
+				return nil, err
+			}
+			typeParams = append(typeParams, tag)
+		}
+	}
+	parts := strings.Split(base, "::")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" || !utils.IsValidSuiAddress(utils.NormalizeSuiAddress(parts[0])) {
+		return nil, fmt.Errorf("invalid type tag: %s", str)
+	}
+	return map[string]any{
+		"address":    utils.NormalizeSuiAddress(parts[0]),
+		"module":     parts[1],
+		"name":       parts[2],
+		"typeParams": typeParams,
+	}, nil
+}
+
+func typeTagToString(tag any) (string, error) {
+	m, ok := tag.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("invalid type tag: %v", tag)
+	}
+	kind, _ := m["$kind"].(string)
+	if primitiveTypeTags[kind] {
+		return kind, nil
+	}
+	switch kind {
+	case "vector":
+		inner, err := typeTagToString(m["vector"])
+		if err != nil {
+			return "", err
+		}
+		return "vector<" + inner + ">", nil
+	case "struct":
+		st, ok := m["struct"].(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("invalid struct tag: %v", m["struct"])
+		}
+		address, _ := st["address"].(string)
+		module, _ := st["module"].(string)
+		name, _ := st["name"].(string)
+		out := utils.NormalizeSuiAddress(address) + "::" + module + "::" + name
+		params, _ := st["typeParams"].([]any)
+		if len(params) == 0 {
+			return out, nil
+		}
+		strs := make([]string, len(params))
+		for i, p := range params {
+			s, err := typeTagToString(p)
+			if err != nil {
+				return "", err
+			}
+			strs[i] = s
+		}
+		return out + "<" + strings.Join(strs, ", ") + ">", nil
+	default:
+		return "", fmt.Errorf("invalid type tag kind: %q", kind)
+	}
+}
@@ -0,0 +1,94 @@
+package transactions
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sui-sdks/go-sdks/sui/cryptography"
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+// Upgrade adds an Upgrade command consuming an UpgradeTicket and returns the
+// UpgradeReceipt that must be passed to commit_upgrade.
+func (t *Transaction) Upgrade(modules [][]byte, dependencies []string, packageID string, ticket Argument) Argument {
+	return t.AddCommand(TransactionCommands.Upgrade(modules, dependencies, packageID, ticket))
+}
+
+// UpgradePackageOptions describes a package upgrade authorized by an UpgradeCap.
+type UpgradePackageOptions struct {
+	// UpgradeCap is anything accepted by Transaction.Object.
+	UpgradeCap   any
+	Package      string
+	Modules      [][]byte
+	Dependencies []string
+	// Digest is the package digest reported by `sui move build
+	// --dump-bytecode-as-base64`. When empty it is computed from Modules and
+	// Dependencies.
+	Digest []byte
+	// Policy is one of the UpgradePolicy constants.
+	Policy uint8
+}
+
+// UpgradePackage adds the authorize_upgrade → Upgrade → commit_upgrade
+// sequence. The UpgradeReceipt is consumed by commit_upgrade, so nothing is
+// returned for later commands to use.
+func (t *Transaction) UpgradePackage(opts UpgradePackageOptions) error {
+	if opts.UpgradeCap == nil {
+		return fmt.Errorf("missing upgrade cap")
+	}
+	if !utils.IsValidSuiObjectID(utils.NormalizeSuiObjectID(opts.Package)) {
+		return fmt.Errorf("invalid package id: %q", opts.Package)
+	}
+	if len(opts.Modules) == 0 {
+		return fmt.Errorf("upgrade requires at least one module")
+	}
+	switch opts.Policy {
+	case UpgradePolicyCompatible, UpgradePolicyAdditive, UpgradePolicyDepOnly:
+	default:
+		return fmt.Errorf("invalid upgrade policy: %d", opts.Policy)
+	}
+	digest := opts.Digest
+	if len(digest) == 0 {
+		computed, err := ComputePackageDigest(opts.Modules, opts.Dependencies)
+		if err != nil {
+			return err
+		}
+		digest = computed
+	}
+	if len(digest) != 32 {
+		return fmt.Errorf("invalid package digest length: %d", len(digest))
+	}
+
+	upgradeCap := t.Object(opts.UpgradeCap)
+	digestArg, err := t.Pure().Vector("u8", digest)
+	if err != nil {
+		return err
+	}
+	ticket := t.MoveCall("0x2::package::authorize_upgrade", []Argument{upgradeCap, t.Pure().U8(opts.Policy), digestArg}, nil)
+	receipt := t.Upgrade(opts.Modules, opts.Dependencies, opts.Package, ticket)
+	t.MoveCall("0x2::package::commit_upgrade", []Argument{upgradeCap, receipt}, nil)
+	return nil
+}
+
+// ComputePackageDigest returns the digest Sui assigns to a package: the
+// Blake2b-256 hash of the sorted module hashes and dependency IDs.
+func ComputePackageDigest(modules [][]byte, dependencies []string) ([]byte, error) {
+	components := make([][]byte, 0, len(modules)+len(dependencies))
+	for _, m := range modules {
+		d := cryptography.Blake2b256(m)
+		components = append(components, d[:])
+	}
+	for _, dep := range dependencies {
+		if !utils.IsValidSuiObjectID(utils.NormalizeSuiObjectID(dep)) {
+			return nil, fmt.Errorf("invalid dependency id: %q", dep)
+		}
+		id, _ := hex.DecodeString(strings.TrimPrefix(utils.NormalizeSuiObjectID(dep), "0x"))
+		components = append(components, id)
+	}
+	sort.Slice(components, func(i, j int) bool { return bytes.Compare(components[i], components[j]) < 0 })
+	digest := cryptography.Blake2b256(components...)
+	return digest[:], nil
+}
@@ -0,0 +1,85 @@
+package transactions
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/sui-sdks/go-sdks/sui/utils"
+)
+
+func TestComputePackageDigest(t *testing.T) {
+	modules := [][]byte{{1, 2, 3}, []byte("module-b")}
+	// Expected value computed independently from the Sui package digest definition.
+	want := "51ee3d05c4ef716a0db98f372628a4490d2119212e8b8817f5edc5658120fd46"
+	digest, err := ComputePackageDigest(modules, []string{"0x1", "0x2"})
+	if err != nil {
+		t.Fatalf("digest failed: %v", err)
+	}
+	if got := hex.EncodeToString(digest); got != want {
+		t.Fatalf("digest mismatch: got %s want %s", got, want)
+	}
+	reordered, _ := ComputePackageDigest([][]byte{modules[1], modules[0]}, []string{"0x2", "0x1"})
+	if !bytes.Equal(reordered, digest) {
+		t.Fatalf("digest must not depend on module or dependency order")
+	}
+	if _, err := ComputePackageDigest(modules, []string{"not-an-id"}); err == nil {
+		t.Fatalf("expected error for invalid dependency")
+	}
+}
+
+func TestUpgradePackage(t *testing.T) {
+	tx := NewTransaction()
+	tx.SetSender("0x1")
+	tx.SetGasPrice(1)
+	tx.SetGasBudget(1)
+	tx.SetGasPayment([]ObjectRef{{ObjectID: "0x5", Version: "7", Digest: testDigest}})
+	modules := [][]byte{{0xa1, 0x1c}}
+	err := tx.UpgradePackage(UpgradePackageOptions{
+		UpgradeCap:   Inputs.ObjectRef(ObjectRef{ObjectID: "0xca", Version: "3", Digest: testDigest}),
+		Package:      "0x42",
+		Modules:      modules,
+		Dependencies: []string{"0x1", "0x2"},
+		Policy:       UpgradePolicyAdditive,
+	})
+	if err != nil {
+		t.Fatalf("upgrade failed: %v", err)
+	}
+	data := tx.GetData()
+	if len(data.Commands) != 3 {
+		t.Fatalf("expected 3 commands, got %d", len(data.Commands))
+	}
+	authorize, upgrade, commit := data.Commands[0].MoveCall, data.Commands[1].Upgrade, data.Commands[2].MoveCall
+	if authorize == nil || authorize.Function != "authorize_upgrade" || commit == nil || commit.Function != "commit_upgrade" {
+		t.Fatalf("unexpected command sequence: %+v", data.Commands)
+	}
+	if upgrade == nil || upgrade.Package != utils.NormalizeSuiObjectID("0x42") || upgrade.Ticket != ResultArgument(0) {
+		t.Fatalf("unexpected upgrade command: %+v", upgrade)
+	}
+	if commit.Arguments[0] != authorize.Arguments[0] || commit.Arguments[1] != ResultArgument(1) {
+		t.Fatalf("commit_upgrade must take the cap and the receipt: %+v", commit.Arguments)
+	}
+	if got := pureInputHex(t, tx, authorize.Arguments[1]); got != "80" {
+		t.Fatalf("unexpected policy bytes: %s", got)
+	}
+	digest, _ := ComputePackageDigest(modules, []string{"0x1", "0x2"})
+	if got := pureInputHex(t, tx, authorize.Arguments[2]); got != "20"+hex.EncodeToString(digest) {
+		t.Fatalf("unexpected digest bytes: %s", got)
+	}
+
+	built, err := tx.Build()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+	restored, err := TransactionFromBytes(built)
+	if err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if u := restored.GetData().Commands[1].Upgrade; u == nil || !bytes.Equal(u.Modules[0], modules[0]) || len(u.Dependencies) != 2 {
+		t.Fatalf("upgrade command did not round-trip: %+v", restored.GetData().Commands[1])
+	}
+
+	if err := NewTransaction().UpgradePackage(UpgradePackageOptions{UpgradeCap: "0xca", Package: "0x42", Modules: modules, Policy: 1}); err == nil {
+		t.Fatalf("expected error for invalid policy")
+	}
+}